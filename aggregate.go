@@ -0,0 +1,148 @@
+package warnings
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// SourceKey is the key of the attribute recording the source(s) of a warning
+// added to an Aggregator, as a []string.
+const SourceKey = "source"
+
+// An Aggregator merges the Lists of many workers or processes into one, such
+// as the Lists written by the shards of a batch job. Each warning is
+// attributed to its source using an attribute with key SourceKey (see
+// SourcesOf and BySource).
+type Aggregator struct {
+	// Dedup set to true means that a warning identical (see Fingerprint) to
+	// one already added is only counted in the Count of that one, and its
+	// source added to the sources of that one.
+	Dedup bool
+	// FatalPolicy determines how the fatal errors of several Lists are
+	// merged; by default, all of them are kept.
+	FatalPolicy FatalPolicy
+
+	l    List
+	seen map[string]int // per fingerprint, index in l.Warnings
+}
+
+// Add merges l from source into a. The counts of l are added up; the fatal
+// errors of l are added to those of a according to a.FatalPolicy.
+func (a *Aggregator) Add(source string, l List) {
+	for _, err := range l.Warnings {
+		a.add(source, err)
+	}
+	for _, err := range l.FatalErrors() {
+		a.l.mergeFatal(AddAttrs(err, Field(SourceKey, []string{source})), a.FatalPolicy)
+	}
+	a.l.Drained += l.Drained
+	a.l.Omitted += l.Omitted
+	a.l.Expired += l.Expired
+	a.l.Total += l.Total
+}
+
+func (a *Aggregator) add(source string, err error) {
+	if !a.Dedup {
+		a.l.Warnings = append(a.l.Warnings, AddAttrs(err, Field(SourceKey, []string{source})))
+		return
+	}
+	fp := Fingerprint(err)
+	if i, ok := a.seen[fp]; ok {
+		// copied, as the Warning may be held by Lists returned earlier
+		w := *a.l.Warnings[i].(*Warning) // added by AddAttrs
+		w.Count = countOf(&w) + countOf(err)
+		addSource(&w, source)
+		a.l.Warnings[i] = &w
+		return
+	}
+	if a.seen == nil {
+		a.seen = make(map[string]int)
+	}
+	a.seen[fp] = len(a.l.Warnings)
+	a.l.Warnings = append(a.l.Warnings, AddAttrs(err, Field(SourceKey, []string{source})))
+}
+
+// ReadFrom reads Lists from r, as written by Collector.Save or WriteHandoff
+// (one JSON value per List), and adds them to a until EOF.
+func (a *Aggregator) ReadFrom(source string, r io.Reader) error {
+	d := json.NewDecoder(r)
+	for {
+		var l List
+		if err := d.Decode(&l); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		a.Add(source, l)
+	}
+}
+
+// Consume adds the Lists received from ch to a until ch is closed.
+func (a *Aggregator) Consume(source string, ch <-chan List) {
+	for l := range ch {
+		a.Add(source, l)
+	}
+}
+
+// List returns the merged List.
+func (a *Aggregator) List() List {
+	l := a.l
+	l.Warnings = append([]error(nil), a.l.Warnings...)
+	l.Fatals = append([]error(nil), a.l.Fatals...)
+	return l
+}
+
+// addSource adds source to the sources of w, unless already included. The
+// attributes of w are copied rather than modified.
+func addSource(w *Warning, source string) {
+	sources := SourcesOf(w)
+	for _, s := range sources {
+		if s == source {
+			return
+		}
+	}
+	sources = append(sources[:len(sources):len(sources)], source)
+	w.Attrs = append([]Attr(nil), w.Attrs...)
+	for i := len(w.Attrs) - 1; i >= 0; i-- {
+		if w.Attrs[i].Key == SourceKey {
+			w.Attrs[i].Value = sources
+			return
+		}
+	}
+	w.Attrs = append(w.Attrs, Field(SourceKey, sources))
+}
+
+// SourcesOf returns the sources err was attributed to by an Aggregator, in
+// the order they were added. The sources of a List decoded from JSON are
+// found as well.
+func SourcesOf(err error) []string {
+	attrs := AttrsOf(err)
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key != SourceKey {
+			continue
+		}
+		switch v := attrs[i].Value.(type) {
+		case []string:
+			return v
+		case []interface{}: // decoded from JSON
+			sources := make([]string, 0, len(v))
+			for _, s := range v {
+				if s, ok := s.(string); ok {
+					sources = append(sources, s)
+				}
+			}
+			return sources
+		case string:
+			return []string{v}
+		}
+		return nil
+	}
+	return nil
+}
+
+// BySource is a key function for GroupBy; it returns the sources err was
+// attributed to by an Aggregator, comma-separated, or "" if none.
+func BySource(err error) string {
+	return strings.Join(SourcesOf(err), ",")
+}
@@ -0,0 +1,100 @@
+package warnings_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestAggregator(t *testing.T) {
+	for _, dedup := range []bool{false, true} {
+		a := w.Aggregator{Dedup: dedup}
+		a.Add("s1", w.List{Warnings: []error{warning("1w"), warning("2w")},
+			Omitted: 1})
+		ch := make(chan w.List, 1)
+		ch <- w.List{Warnings: []error{warning("1w")}, Fatal: fatal("3f")}
+		close(ch)
+		a.Consume("s2", ch)
+		r := strings.NewReader(`{"warnings":[{"message":"4w"}],"fatal":{"message":"5f"}}
+{"warnings":[{"message":"1w","count":2}],"drained":3}`)
+		if err := a.ReadFrom("s3", r); err != nil {
+			t.Fatal(err)
+		}
+		l := a.List()
+		var want string
+		var wantSources []string
+		if dedup {
+			want = "fatal:\n3f\n5f\nwarnings:\n(3 earlier warning(s) already written)\n" +
+				"1w (3 more times)\n2w\n4w\n(1 more warning(s) omitted)\n"
+			wantSources = []string{"s1,s2,s3", "s1", "s3"}
+		} else {
+			want = "fatal:\n3f\n5f\nwarnings:\n(3 earlier warning(s) already written)\n" +
+				"1w\n2w\n1w\n4w\n1w (1 more times)\n(1 more warning(s) omitted)\n"
+			wantSources = []string{"s1", "s1", "s2", "s3", "s3"}
+		}
+		if l.Error() != want {
+			t.Errorf("Dedup=%v: List() = %q; want %q", dedup, l.Error(), want)
+		}
+		var sources []string
+		for _, err := range l.Warnings {
+			sources = append(sources, w.BySource(err))
+		}
+		if !reflect.DeepEqual(sources, wantSources) || w.BySource(l.Fatal) != "s2" {
+			t.Errorf("Dedup=%v: sources = %v, fatal %v; want %v, s2", dedup,
+				sources, w.BySource(l.Fatal), wantSources)
+		}
+	}
+}
+
+func TestAggregatorSources(t *testing.T) {
+	a := w.Aggregator{Dedup: true}
+	a.Add("a,b", w.List{Warnings: []error{warning("1w")}})
+	l := a.List()
+	a.Add("c", w.List{Warnings: []error{warning("1w")}})
+	if got := w.SourcesOf(l.Warnings[0]); !reflect.DeepEqual(got, []string{"a,b"}) {
+		t.Errorf("SourcesOf() = %q after Add; want earlier List unchanged", got)
+	}
+	if ww := l.Warnings[0].(*w.Warning); ww.Count != 0 {
+		t.Errorf("Count = %d after Add; want earlier List unchanged", ww.Count)
+	}
+	got := w.SourcesOf(a.List().Warnings[0])
+	if want := []string{"a,b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SourcesOf() = %q; want %q", got, want)
+	}
+}
+
+func TestAggregatorReadFromError(t *testing.T) {
+	var a w.Aggregator
+	if err := a.ReadFrom("s", strings.NewReader("{")); err == nil {
+		t.Errorf("ReadFrom() = nil; want error")
+	}
+}
+
+func TestSourcesOf(t *testing.T) {
+	a := w.Aggregator{Dedup: true}
+	a.Add("s1", w.List{Warnings: []error{warning("1w")}})
+	a.Add("s2", w.List{Warnings: []error{warning("1w"), warning("2w")}})
+	a.Add("s1", w.List{Warnings: []error{warning("1w")}})
+	l := a.List()
+	got, want := w.SourcesOf(l.Warnings[0]), []string{"s1", "s2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SourcesOf() = %v; want %v", got, want)
+	}
+	if got := w.SourcesOf(l.Warnings[1]); !reflect.DeepEqual(got, []string{"s2"}) {
+		t.Errorf("SourcesOf() = %v; want [s2]", got)
+	}
+	if got := w.SourcesOf(warning("3w")); got != nil {
+		t.Errorf("SourcesOf(unattributed) = %v; want nil", got)
+	}
+	b := bytes.NewBuffer(nil)
+	if err := w.WriteGroups(b, l.GroupBy(w.BySource)); err != nil {
+		t.Fatal(err)
+	}
+	wantGroups := "s1,s2:\n  warning:\n  1w (2 more times)\ns2:\n  warning:\n  2w\n"
+	if b.String() != wantGroups {
+		t.Errorf("WriteGroups() = %q; want %q", b, wantGroups)
+	}
+}
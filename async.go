@@ -0,0 +1,102 @@
+package warnings
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// An Async delivers events to sinks (such as loggers, reporters or metrics)
+// on a separate goroutine, so that collecting stays cheap on latency-critical
+// paths. Its Hook method is meant for use as Collector.Hook; it may be shared
+// by several Collectors:
+//
+//	a := warnings.NewAsync(1024, warnings.NDJSONHook(os.Stderr))
+//	defer a.Close()
+//	c.Hook = a.Hook
+type Async struct {
+	queue   chan asyncItem
+	done    chan struct{}
+	sinks   []func(Event)
+	dropped int64
+	mu      sync.RWMutex // guards closing queue against Hook and Flush
+	closed  bool
+}
+
+// asyncItem is an event to deliver, or a Flush request.
+type asyncItem struct {
+	e       Event
+	flushed chan struct{} // closed once reached, for Flush
+}
+
+// NewAsync returns an Async delivering events to sinks, in order, queueing up
+// to buffer events.
+func NewAsync(buffer int, sinks ...func(Event)) *Async {
+	a := &Async{
+		queue: make(chan asyncItem, buffer),
+		done:  make(chan struct{}),
+		sinks: sinks,
+	}
+	go a.deliver()
+	return a
+}
+
+// Hook queues e for delivery. It never blocks: if the queue is full, e is
+// dropped and only counted (see Dropped). After Close, e is dropped
+// silently.
+func (a *Async) Hook(e Event) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return
+	}
+	select {
+	case a.queue <- asyncItem{e: e}:
+	default:
+		atomic.AddInt64(&a.dropped, 1)
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (a *Async) Dropped() int {
+	return int(atomic.LoadInt64(&a.dropped))
+}
+
+// Flush waits until all events queued so far have been delivered. After
+// Close, it waits for Close to complete.
+func (a *Async) Flush() {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		<-a.done
+		return
+	}
+	flushed := make(chan struct{})
+	a.queue <- asyncItem{flushed: flushed}
+	a.mu.RUnlock()
+	<-flushed
+}
+
+// Close delivers all queued events and stops the delivery goroutine. It may
+// be called more than once, also concurrently with Hook and Flush.
+func (a *Async) Close() {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+	<-a.done
+}
+
+func (a *Async) deliver() {
+	defer close(a.done)
+	for it := range a.queue {
+		if it.flushed != nil {
+			close(it.flushed)
+			continue
+		}
+		for _, sink := range a.sinks {
+			sink(it.e)
+		}
+	}
+}
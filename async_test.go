@@ -0,0 +1,70 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestAsync(t *testing.T) {
+	var got []string
+	b := bytes.NewBuffer(nil)
+	a := w.NewAsync(16, func(e w.Event) { got = append(got, e.Err.Error()) },
+		w.NDJSONHook(b))
+	c := w.NewCollector(isFatal)
+	c.Hook = a.Hook
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	a.Flush()
+	if len(got) != 2 {
+		t.Errorf("after Flush, delivered %q; want 2 events", got)
+	}
+	c.Collect(fatal("3f"))
+	a.Close()
+	if want := []string{"1w", "2w", "3f"}; len(got) != 3 || got[2] != want[2] {
+		t.Errorf("after Close, delivered %q; want %q", got, want)
+	}
+	if n := bytes.Count(b.Bytes(), []byte("\n")); n != 3 {
+		t.Errorf("NDJSON sink wrote %d lines; want 3", n)
+	}
+	if n := a.Dropped(); n != 0 {
+		t.Errorf("Dropped() = %d; want 0", n)
+	}
+}
+
+func TestAsyncDropped(t *testing.T) {
+	block := make(chan struct{})
+	a := w.NewAsync(1, func(w.Event) { <-block })
+	for i := 0; i < 5; i++ {
+		a.Hook(w.Event{Err: warning("w")})
+	}
+	close(block)
+	a.Close()
+	// One event is being delivered and one queued; at least the rest is
+	// dropped.
+	if n := a.Dropped(); n < 3 {
+		t.Errorf("Dropped() = %d; want >= 3", n)
+	}
+}
+
+func TestAsyncClosed(t *testing.T) {
+	n := 0
+	a := w.NewAsync(1, func(w.Event) { n++ })
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			a.Hook(w.Event{Err: warning("1w")})
+			a.Flush()
+		}
+	}()
+	a.Close()
+	<-done
+	a.Close()
+	a.Hook(w.Event{Err: warning("2w")})
+	a.Flush()
+	if a.Dropped() != 0 {
+		t.Errorf("Dropped() = %d after Close; want 0", a.Dropped())
+	}
+}
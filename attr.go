@@ -0,0 +1,53 @@
+package warnings
+
+import (
+	"errors"
+	"fmt"
+)
+
+// An Attr is a key/value attribute of a Warning.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// Field returns an Attr for key and value, for passing to Collect:
+//
+//	c.Collect(err, warnings.Field("table", name))
+func Field(key string, value interface{}) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// String returns "key=value".
+func (a Attr) String() string {
+	return fmt.Sprintf("%s=%v", a.Key, a.Value)
+}
+
+// AddAttrs returns err with attrs attached. If err is a *Warning, a copy of it
+// with attrs appended is returned; otherwise err is wrapped in a *Warning
+// with the same message, code and severity. If attrs is empty, err is
+// returned unchanged.
+func AddAttrs(err error, attrs ...Attr) error {
+	if err == nil || len(attrs) == 0 {
+		return err
+	}
+	w := copyWarning(err)
+	w.Attrs = append(append([]Attr(nil), w.Attrs...), attrs...)
+	return w
+}
+
+// WithAttrs adds ambient attributes (such as a trace, tenant or job ID) that
+// are attached to each error collected from now on, before the attrs passed
+// to Collect.
+func (c *Collector) WithAttrs(attrs ...Attr) {
+	c.attrs = append(c.attrs, attrs...)
+}
+
+// AttrsOf returns the attributes of the first *Warning in the chain of err.
+func AttrsOf(err error) []Attr {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w.Attrs
+	}
+	return nil
+}
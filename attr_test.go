@@ -0,0 +1,68 @@
+package warnings_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestCollectAttrs(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	base := &w.Warning{Code: "C1", Msg: "msg", Attrs: []w.Attr{w.Field("a", 1)}}
+	c.Collect(warning("1w"), w.Field("table", "users"))
+	c.Collect(base, w.Field("b", "x"))
+	f := fatal("2f")
+	c.Collect(f, w.Field("c", true))
+	_, warns := w.Split(c.Done())
+	if len(warns) != 2 {
+		t.Fatalf("got warnings %v; want 2", warns)
+	}
+	if !errors.Is(warns[0], warning("1w")) || warns[0].Error() != "1w" {
+		t.Errorf("warning %v doesn't wrap the collected error", warns[0])
+	}
+	if want := []w.Attr{{"table", "users"}}; !reflect.DeepEqual(w.AttrsOf(warns[0]), want) {
+		t.Errorf("AttrsOf(%v) = %v; want %v", warns[0], w.AttrsOf(warns[0]), want)
+	}
+	if want := []w.Attr{{"a", 1}, {"b", "x"}}; !reflect.DeepEqual(w.AttrsOf(warns[1]), want) {
+		t.Errorf("AttrsOf(%v) = %v; want %v", warns[1], w.AttrsOf(warns[1]), want)
+	}
+	if len(base.Attrs) != 1 {
+		t.Errorf("Collect modified the collected *Warning: %v", base.Attrs)
+	}
+	data, err := json.Marshal(warns[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"code":"C1","message":"msg","attrs":{"a":1,"b":"x"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s; want %s", data, want)
+	}
+}
+
+func TestWithAttrs(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.Collect(warning("1w"))
+	c.WithAttrs(w.Field("trace", "t1"))
+	c.WithAttrs(w.Field("tenant", "acme"))
+	c.Collect(warning("2w"), w.Field("table", "users"))
+	warns := c.Warnings()
+	if got := w.AttrsOf(warns[0]); got != nil {
+		t.Errorf("AttrsOf(%v) = %v; want none", warns[0], got)
+	}
+	want := []w.Attr{w.Field("trace", "t1"), w.Field("tenant", "acme"),
+		w.Field("table", "users")}
+	if got := w.AttrsOf(warns[1]); !reflect.DeepEqual(got, want) {
+		t.Errorf("AttrsOf(%v) = %v; want %v", warns[1], got, want)
+	}
+	b, err := json.Marshal(warns[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"trace":"t1"`; !bytes.Contains(b, []byte(want)) {
+		t.Errorf("Marshal() = %s; want it to contain %s", b, want)
+	}
+}
@@ -0,0 +1,138 @@
+package warnings
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// An Outcome is the result of a single item of a batch.
+type Outcome int
+
+// Outcomes, in increasing order of severity.
+const (
+	OK     Outcome = iota // no errors
+	Warned                // warnings only
+	Failed                // a fatal error
+)
+
+var outcomeNames = map[Outcome]string{OK: "ok", Warned: "warned",
+	Failed: "failed"}
+
+// String returns the name of o ("ok", "warned" or "failed").
+func (o Outcome) String() string {
+	if name, ok := outcomeNames[o]; ok {
+		return name
+	}
+	return "outcome(" + strconv.Itoa(int(o)) + ")"
+}
+
+// A BatchCollector hands out a Collector per item of a batch (such as the
+// records of a bulk request or an ETL load), and reports the outcome of each
+// item as well as an aggregate of all of them:
+//
+//	b := warnings.NewBatchCollector(isFatal)
+//	for i, rec := range records {
+//		load(b.Index(i), rec)
+//	}
+//	items, summary := b.Done()
+type BatchCollector struct {
+	// IsFatal distinguishes between warnings and fatal errors.
+	IsFatal func(error) bool
+	// MaxPerKey, if > 0, limits the number of warnings kept per item, as
+	// Collector.MaxWarnings does.
+	MaxPerKey int
+
+	cs   map[string]*Collector
+	keys []string // in the order handed out
+	done bool
+}
+
+// NewBatchCollector returns a new BatchCollector; it uses isFatal to
+// distinguish between warnings and fatal errors.
+func NewBatchCollector(isFatal func(error) bool) *BatchCollector {
+	return &BatchCollector{IsFatal: isFatal}
+}
+
+// Item returns the Collector for the item with the given key, creating it on
+// first use. The Collector mustn't be used after Done has been called.
+func (b *BatchCollector) Item(key string) *Collector {
+	if b.done {
+		panic("warnings.BatchCollector already done")
+	}
+	c, ok := b.cs[key]
+	if !ok {
+		if b.cs == nil {
+			b.cs = make(map[string]*Collector)
+		}
+		c = &Collector{IsFatal: b.IsFatal, MaxWarnings: b.MaxPerKey}
+		b.cs[key] = c
+		b.keys = append(b.keys, key)
+	}
+	return c
+}
+
+// Index returns the Collector for the i-th item, i.e. Item(strconv.Itoa(i)).
+func (b *BatchCollector) Index(i int) *Collector {
+	return b.Item(strconv.Itoa(i))
+}
+
+// Outcome returns the outcome of the item with the given key so far. Items
+// never handed out are OK.
+func (b *BatchCollector) Outcome(key string) Outcome {
+	c, ok := b.cs[key]
+	switch {
+	case !ok:
+		return OK
+	case c.l.Fatal != nil:
+		return Failed
+	case c.l.count() > 0:
+		return Warned
+	}
+	return OK
+}
+
+// A BatchItem is the result of a single item of a batch.
+type BatchItem struct {
+	Key     string
+	Outcome Outcome
+	List    List
+}
+
+// A BatchSummary aggregates the results of all items of a batch.
+type BatchSummary struct {
+	OK, Warned, Failed int // number of items per outcome
+	// List holds the warnings and fatal errors of all items, attributed to
+	// their items as by an Aggregator (see SourcesOf).
+	List List
+}
+
+// String returns a summary such as "3 ok, 1 warned, 1 failed".
+func (s BatchSummary) String() string {
+	return fmt.Sprintf("%d ok, %d warned, %d failed", s.OK, s.Warned, s.Failed)
+}
+
+// Done ends collection for all items, and returns the result of each item
+// handed out, in the order they were first handed out, together with their
+// aggregate.
+func (b *BatchCollector) Done() ([]BatchItem, BatchSummary) {
+	b.done = true
+	var agg Aggregator
+	var s BatchSummary
+	items := make([]BatchItem, len(b.keys))
+	for i, key := range b.keys {
+		c := b.cs[key]
+		c.Done()
+		items[i] = BatchItem{key, b.Outcome(key), c.List()}
+		switch items[i].Outcome {
+		case OK:
+			s.OK++
+		case Warned:
+			s.Warned++
+		case Failed:
+			s.Failed++
+		}
+		agg.Add(key, items[i].List)
+	}
+	s.List = agg.List()
+	return items, s
+}
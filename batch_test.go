@@ -0,0 +1,76 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestBatchCollector(t *testing.T) {
+	b := w.NewBatchCollector(isFatal)
+	b.Index(0)
+	b.Index(1).Collect(warning("1w"))
+	b.Item("x").Collect(fatal("xf"))
+	b.Index(1).Collect(warning("1w2"))
+	if got := b.Outcome("1"); got != w.Warned {
+		t.Errorf("Outcome(1) = %v; want %v", got, w.Warned)
+	}
+	if got := b.Outcome("missing"); got != w.OK {
+		t.Errorf("Outcome(missing) = %v; want %v", got, w.OK)
+	}
+	items, s := b.Done()
+	var keys []string
+	var outcomes []w.Outcome
+	for _, it := range items {
+		keys = append(keys, it.Key)
+		outcomes = append(outcomes, it.Outcome)
+	}
+	if want := []string{"0", "1", "x"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Done() keys = %q; want %q", keys, want)
+	}
+	if want := []w.Outcome{w.OK, w.Warned, w.Failed}; !reflect.DeepEqual(outcomes, want) {
+		t.Errorf("Done() outcomes = %v; want %v", outcomes, want)
+	}
+	if n := len(items[1].List.Warnings); n != 2 {
+		t.Errorf("item 1 has %d warnings; want 2", n)
+	}
+	if want := "1 ok, 1 warned, 1 failed"; s.String() != want {
+		t.Errorf("summary = %q; want %q", s, want)
+	}
+	if n := len(s.List.Warnings); n != 2 || s.List.Fatal == nil {
+		t.Errorf("summary List = %v; want 2 warnings and a fatal error", s.List)
+	}
+	if got := w.SourcesOf(s.List.Fatal); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("SourcesOf(fatal) = %q; want [x]", got)
+	}
+}
+
+func TestBatchCollectorMaxPerKey(t *testing.T) {
+	b := w.NewBatchCollector(isFatal)
+	b.MaxPerKey = 1
+	for i := 0; i < 3; i++ {
+		b.Index(0).Collect(warning("w"))
+	}
+	items, s := b.Done()
+	if l := items[0].List; len(l.Warnings) != 1 || l.Omitted != 2 {
+		t.Errorf("item 0 = %v; want 1 warning, 2 omitted", l)
+	}
+	if s.List.Omitted != 2 {
+		t.Errorf("summary Omitted = %d; want 2", s.List.Omitted)
+	}
+}
+
+func TestBatchCollectorDone(t *testing.T) {
+	b := w.NewBatchCollector(isFatal)
+	c := b.Index(0)
+	events := c.Events()
+	c.Finalize = func(l w.List) error { return fatal("gate") }
+	c.Collect(warning("1w"))
+	items, _ := b.Done()
+	for range events {
+	}
+	if items[0].Outcome != w.Failed || items[0].List.Fatal.Error() != "gate" {
+		t.Errorf("item 0 = %v, %v; want failed by Finalize", items[0].Outcome, items[0].List)
+	}
+}
@@ -0,0 +1,63 @@
+package warnings
+
+import "fmt"
+
+// A Builder builds a *Warning step by step:
+//
+//	w := warnings.New("CFG001").At(pos).Msg("unknown key %q", key).
+//		Hint("https://example.com/docs/CFG001").Warning()
+type Builder struct {
+	w Warning
+}
+
+// New returns a Builder for a Warning with the given code.
+func New(code string) *Builder {
+	return &Builder{Warning{Code: code}}
+}
+
+// Severity sets the severity.
+func (b *Builder) Severity(s Severity) *Builder {
+	b.w.Severity = s
+	return b
+}
+
+// At sets the position.
+func (b *Builder) At(pos Position) *Builder {
+	b.w.Pos = pos
+	return b
+}
+
+// Msg sets the message, formatted as by fmt.Sprintf.
+func (b *Builder) Msg(format string, args ...interface{}) *Builder {
+	b.w.Msg = fmt.Sprintf(format, args...)
+	return b
+}
+
+// Hint sets the hint.
+func (b *Builder) Hint(hint string) *Builder {
+	b.w.Hint = hint
+	return b
+}
+
+// Cause sets the underlying cause.
+func (b *Builder) Cause(err error) *Builder {
+	b.w.Err = err
+	return b
+}
+
+// Attr adds an attribute.
+func (b *Builder) Attr(key string, value interface{}) *Builder {
+	b.w.Attrs = append(b.w.Attrs, Attr{key, value})
+	return b
+}
+
+// Warning returns the Warning built so far. The Builder may be used further
+// without affecting the returned Warning.
+func (b *Builder) Warning() *Warning {
+	w := b.w
+	w.Attrs = append([]Attr(nil), b.w.Attrs...)
+	if len(w.Attrs) == 0 {
+		w.Attrs = nil
+	}
+	return &w
+}
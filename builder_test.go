@@ -0,0 +1,30 @@
+package warnings_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestBuilder(t *testing.T) {
+	cause := errors.New("cause")
+	pos := w.Position{Filename: "f", Line: 1, Column: 2}
+	b := w.New("CFG001").Severity(w.Error).At(pos).Msg("bad %q", "key").
+		Hint("https://example.com/CFG001").Cause(cause).Attr("k", 1)
+	want := &w.Warning{Code: "CFG001", Severity: w.Error, Pos: pos,
+		Msg: `bad "key"`, Hint: "https://example.com/CFG001", Err: cause,
+		Attrs: []w.Attr{{"k", 1}}}
+	got := b.Warning()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warning() = %#v; want %#v", got, want)
+	}
+	b.Attr("k2", 2)
+	if len(got.Attrs) != 1 {
+		t.Errorf("reusing Builder modified the built Warning: %v", got.Attrs)
+	}
+	if got := w.New("C2").Warning(); !reflect.DeepEqual(got, &w.Warning{Code: "C2"}) {
+		t.Errorf("Warning() = %#v; want code only", got)
+	}
+}
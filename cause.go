@@ -0,0 +1,49 @@
+package warnings
+
+// WithCause makes c wrap each error collected from now on in a *CausedError
+// with the given cause (such as an error "while reloading config"), so that
+// errors.Is(err, cause) reports true for any of them later. If cause is nil,
+// errors are collected unwrapped again.
+func (c *Collector) WithCause(cause error) {
+	c.cause = cause
+}
+
+// A CausedError is an error together with a root cause shared with other
+// errors, as collected after Collector.WithCause.
+type CausedError struct {
+	Err   error
+	Cause error
+}
+
+// Error returns the message of the wrapped error; the cause is not included.
+func (e *CausedError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error and the cause.
+func (e *CausedError) Unwrap() []error { return []error{e.Err, e.Cause} }
+
+// Is reports whether the wrapped error or the cause matches target.
+func (e *CausedError) Is(target error) bool {
+	return (&joinedError{errs: e.Unwrap()}).Is(target)
+}
+
+// As finds the first error in the wrapped error or the cause that matches
+// target.
+func (e *CausedError) As(target interface{}) bool {
+	return (&joinedError{errs: e.Unwrap()}).As(target)
+}
+
+// AsWarning returns the *Warning err is, or wraps in a *CausedError (which
+// doesn't change its rendering), if any. Unlike errors.As, it doesn't look
+// into other wrapping errors, whose message differs from the Warning's. Use
+// it instead of a type assertion when rendering collected errors.
+func AsWarning(err error) (*Warning, bool) {
+	for {
+		ce, ok := err.(*CausedError)
+		if !ok {
+			break
+		}
+		err = ce.Err
+	}
+	w, ok := err.(*Warning)
+	return w, ok
+}
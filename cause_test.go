@@ -0,0 +1,64 @@
+package warnings_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestWithCause(t *testing.T) {
+	reload := errors.New("while reloading config")
+	c := w.NewCollector(isFatalStructured)
+	c.Collect(warning("1w"))
+	c.WithCause(reload)
+	c.Collect(&w.Warning{Code: "C2", Msg: "2w"})
+	c.WithCause(nil)
+	c.Collect(warning("3w"))
+	warns := w.WarningsOnly(c.Done())
+	if len(warns) != 3 {
+		t.Fatalf("Done() = %v; want 3 warnings", warns)
+	}
+	for i, want := range []bool{false, true, false} {
+		if got := errors.Is(warns[i], reload); got != want {
+			t.Errorf("errors.Is(%v, cause) = %v; want %v", warns[i], got, want)
+		}
+	}
+	var ww *w.Warning
+	if !errors.As(warns[1], &ww) || ww.Code != "C2" {
+		t.Errorf("errors.As(%v, *Warning) = %v; want code C2", warns[1], ww)
+	}
+	if got := warns[1].Error(); got != "2w" {
+		t.Errorf("Error() = %q; want %q", got, "2w")
+	}
+}
+
+func TestWithCauseRender(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.WithCause(errors.New("while reloading config"))
+	c.Collect(&w.Warning{Code: "C1", Msg: "m",
+		Pos: w.Position{Filename: "f", Line: 3}})
+	l := c.List()
+	tests := []struct {
+		r    w.Renderer
+		want string
+	}{
+		{w.Text{}, "warning:\nf:3: [C1] m\n"},
+		{w.NDJSON{}, `{"code":"C1","message":"m","file":"f","line":3}` + "\n"},
+		{w.Positional{}, "f:3: warning: [C1] m\n"},
+	}
+	for _, tt := range tests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.r.Render(b, l); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%T: Render() = %q; want %q", tt.r, b, tt.want)
+		}
+	}
+	if got := w.Fingerprint(l.Warnings[0]); got !=
+		w.Fingerprint(&w.Warning{Code: "C1", Msg: "m", Pos: w.Position{Filename: "f", Line: 3}}) {
+		t.Errorf("Fingerprint() differs from that of the unwrapped warning")
+	}
+}
@@ -0,0 +1,75 @@
+package warnings
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+)
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+// WriteCheckstyle writes l to w in checkstyle XML format, with one file
+// element per file name (see ByFile), in sorted order. Codes are written as
+// the source attribute; the fatal error has severity "error".
+func (l List) WriteCheckstyle(w io.Writer) error {
+	groups := l.GroupBy(ByFile)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := checkstyleResult{Version: "4.3"}
+	for _, name := range names {
+		f := checkstyleFile{Name: name}
+		g := groups[name]
+		for _, err := range g.Warnings {
+			f.Errors = append(f.Errors, newCheckstyleError(err, false))
+		}
+		for _, err := range g.FatalErrors() {
+			f.Errors = append(f.Errors, newCheckstyleError(err, true))
+		}
+		res.Files = append(res.Files, f)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newCheckstyleError(err error, fatal bool) checkstyleError {
+	e := checkstyleError{
+		Severity: SeverityOf(err).String(),
+		Message:  messageOf(err),
+		Source:   codeOf(err),
+	}
+	if w, ok := AsWarning(err); ok {
+		e.Line, e.Column = w.Pos.Line, w.Pos.Column
+	}
+	if fatal {
+		e.Severity = Error.String()
+	}
+	return e
+}
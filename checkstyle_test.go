@@ -0,0 +1,42 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestWriteCheckstyle(t *testing.T) {
+	l := w.List{
+		Warnings: []error{
+			&w.Warning{Code: "C1", Msg: "a \"1\"",
+				Pos: w.Position{Filename: "b.cfg", Line: 1, Column: 2}},
+			&w.Warning{Severity: w.Info, Msg: "a2",
+				Pos: w.Position{Filename: "a.cfg", Line: 3}},
+			warning("1w"),
+		},
+		Fatal: &w.Warning{Msg: "f", Pos: w.Position{Filename: "b.cfg", Line: 4}},
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="">
+    <error severity="warning" message="1w"></error>
+  </file>
+  <file name="a.cfg">
+    <error line="3" severity="info" message="a2"></error>
+  </file>
+  <file name="b.cfg">
+    <error line="1" column="2" severity="warning" message="a &#34;1&#34;" source="C1"></error>
+    <error line="4" severity="error" message="f"></error>
+  </file>
+</checkstyle>
+`
+	b := bytes.NewBuffer(nil)
+	if err := l.WriteCheckstyle(b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != want {
+		t.Errorf("WriteCheckstyle() = %s; want %s", b, want)
+	}
+}
@@ -0,0 +1,31 @@
+package warnings
+
+import "time"
+
+// A CollectContext describes the state of a collection at the time an error
+// is classified, as passed to Collector.FatalFunc.
+type CollectContext struct {
+	Count   int           // errors collected before this one (see Metrics.Collected)
+	Elapsed time.Duration // time since the first call to Collect or Phase
+	Phase   string        // name of the current phase (see Collector.Phase), if any
+	Key     string        // key of the error, if collected by a KeyedCollector
+}
+
+// context returns the CollectContext for the error being classified.
+func (c *Collector) context() CollectContext {
+	ctx := CollectContext{Count: c.metrics.Collected, Key: c.key}
+	if !c.started.IsZero() {
+		ctx.Elapsed = c.now().Sub(c.started)
+	}
+	if len(c.phases) > 0 {
+		ctx.Phase = c.phases[len(c.phases)-1].Name
+	}
+	return ctx
+}
+
+// start records the start of collection, for CollectContext.Elapsed.
+func (c *Collector) start() {
+	if c.started.IsZero() {
+		c.started = c.now()
+	}
+}
@@ -0,0 +1,56 @@
+package warnings_test
+
+import (
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestFatalFunc(t *testing.T) {
+	now := time.Unix(0, 0)
+	var ctxs []w.CollectContext
+	c := &w.Collector{FatalFunc: func(ctx w.CollectContext, err error) bool {
+		ctxs = append(ctxs, ctx)
+		return ctx.Phase == "apply" && isFatal(err)
+	}}
+	c.Now = func() time.Time { return now }
+	c.Collect(fatal("1f"))
+	now = now.Add(time.Minute)
+	c.Phase("apply")
+	c.Collect(warning("2w"))
+	now = now.Add(time.Minute)
+	err := c.Collect(fatal("3f"))
+	if err == nil || err.Error() != "3f" {
+		t.Errorf("Collect(3f) = %v; want 3f", err)
+	}
+	want := []w.CollectContext{
+		{Count: 0},
+		{Count: 1, Elapsed: time.Minute, Phase: "apply"},
+		{Count: 2, Elapsed: 2 * time.Minute, Phase: "apply"},
+	}
+	if len(ctxs) != len(want) {
+		t.Fatalf("got %d calls; want %d", len(ctxs), len(want))
+	}
+	for i, ctx := range ctxs {
+		if ctx != want[i] {
+			t.Errorf("call %d: ctx = %+v; want %+v", i, ctx, want[i])
+		}
+	}
+}
+
+func TestKeyedFatalFunc(t *testing.T) {
+	kc := &w.KeyedCollector{FatalFunc: func(ctx w.CollectContext, err error) bool {
+		return ctx.Key == "b" || ctx.Count >= 1
+	}}
+	tests := []struct {
+		key   string
+		fatal bool
+	}{{"a", false}, {"b", true}, {"a", true}}
+	for _, tt := range tests {
+		err := kc.Collect(tt.key, warning("w"))
+		if (err != nil) != tt.fatal {
+			t.Errorf("Collect(%q) = %v; want fatal %v", tt.key, err, tt.fatal)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package cli provides glue for reporting errors returned by a
+// warnings.Collector from command line programs, such as those built with
+// github.com/spf13/cobra.
+//
+// A typical cobra program registers the flags on the root command and
+// handles the error returned by the command:
+//
+//	cli.RegisterFlags(rootCmd.PersistentFlags())
+//	...
+//	cmd, err := rootCmd.ExecuteC()
+//	os.Exit(cli.HandleError(cmd, err))
+package cli // import "gopkg.in/warnings.v0/cli"
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/warnings.v0"
+)
+
+// A Command provides the output stream for errors; it is implemented by
+// *cobra.Command.
+type Command interface {
+	ErrOrStderr() io.Writer
+}
+
+// A FlagSet is a set of flags on which Options can register its flags; it is
+// implemented by *flag.FlagSet and *pflag.FlagSet (as used by cobra).
+type FlagSet interface {
+	BoolVar(p *bool, name string, value bool, usage string)
+}
+
+// Options controls how HandleError reports errors.
+type Options struct {
+	Quiet   bool // don't print warnings
+	Strict  bool // treat warnings as fatal for the exit status
+	NoColor bool // never use colors
+	// Plain set to true means that output is plain ASCII without colors or
+	// other decorations (see warnings.PlainText), e.g. for screen readers.
+	Plain bool
+	// Exit, if set, determines the exit status instead of warnings.ExitCode.
+	Exit *warnings.ExitPolicy
+}
+
+// Default holds the options used by the package-level functions.
+var Default Options
+
+// RegisterFlags registers the flags -quiet, -strict, -no-color and -plain for
+// o on fs.
+func (o *Options) RegisterFlags(fs FlagSet) {
+	fs.BoolVar(&o.Quiet, "quiet", o.Quiet, "don't print warnings")
+	fs.BoolVar(&o.Strict, "strict", o.Strict, "treat warnings as errors")
+	fs.BoolVar(&o.NoColor, "no-color", o.NoColor, "disable colored output")
+	fs.BoolVar(&o.Plain, "plain", o.Plain, "plain ASCII output, e.g. for screen readers")
+}
+
+// RegisterFlags registers the flags for Default on fs.
+func RegisterFlags(fs FlagSet) { Default.RegisterFlags(fs) }
+
+const (
+	yellow = "\x1b[33m"
+	red    = "\x1b[31m"
+	reset  = "\x1b[0m"
+)
+
+// HandleError prints the warnings and the fatal error in err to the error
+// stream of cmd, and returns the exit status for the program (see
+// warnings.ExitCode, or Exit if set). In strict mode, warnings also result
+// in a non-zero exit status. Colors are used if the stream is a terminal,
+// unless disabled by NoColor or the NO_COLOR environment variable; warnings
+// are colored according to their severity (see warnings.SeverityLevel).
+func (o *Options) HandleError(cmd Command, err error) int {
+	if err == nil {
+		return 0
+	}
+	w := cmd.ErrOrStderr()
+	color := !o.NoColor && !o.Plain && os.Getenv("NO_COLOR") == "" &&
+		isTerminal(w)
+	fatal, warns := warnings.Split(err)
+	if !o.Quiet {
+		for _, warn := range warns {
+			c := warnings.SeverityOf(warn).Level().Color
+			if c == "" {
+				c = yellow
+			}
+			o.printError(w, color, c, "warning", warn)
+		}
+	}
+	if fatal != nil {
+		o.printError(w, color, red, "error", fatal)
+	}
+	var code int
+	if o.Exit != nil {
+		code = o.Exit.ExitCode(err)
+	} else {
+		code = warnings.ExitCode(err)
+	}
+	if code == 0 && o.Strict && len(warns) > 0 {
+		code = 1
+	}
+	return code
+}
+
+// HandleError handles err using Default.
+func HandleError(cmd Command, err error) int { return Default.HandleError(cmd, err) }
+
+func (o *Options) printError(w io.Writer, color bool, c, label string, err error) {
+	switch {
+	case o.Plain:
+		fmt.Fprintf(w, "%s: %s\n", label, warnings.PlainText(err.Error()))
+	case color:
+		fmt.Fprintf(w, "%s%s:%s %v\n", c, label, reset, err)
+	default:
+		fmt.Fprintf(w, "%s: %v\n", label, err)
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
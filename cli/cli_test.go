@@ -0,0 +1,68 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/cli"
+)
+
+type command struct{ bytes.Buffer }
+
+func (c *command) ErrOrStderr() io.Writer { return &c.Buffer }
+
+var (
+	w1 = errors.New("1w")
+	f2 = errors.New("2f")
+)
+
+var handleErrorTests = []struct {
+	args []string
+	err  error
+	out  string
+	code int
+}{
+	{nil, nil, "", 0},
+	{nil, warnings.List{Warnings: []error{w1}}, "warning: 1w\n", 0},
+	{nil, warnings.List{Warnings: []error{w1}, Fatal: f2},
+		"warning: 1w\nerror: 2f\n", 1},
+	{[]string{"-quiet"}, warnings.List{Warnings: []error{w1}, Fatal: f2},
+		"error: 2f\n", 1},
+	{[]string{"-strict"}, warnings.List{Warnings: []error{w1}},
+		"warning: 1w\n", 1},
+	{[]string{"-quiet", "-strict"}, warnings.List{Warnings: []error{w1}}, "", 1},
+	{nil, f2, "error: 2f\n", 1},
+	{[]string{"-plain"}, warnings.List{Warnings: []error{errors.New("“1w”")}},
+		"warning: \"1w\"\n", 0},
+}
+
+func TestHandleError(t *testing.T) {
+	for _, tt := range handleErrorTests {
+		var o cli.Options
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		o.RegisterFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		cmd := &command{}
+		code := o.HandleError(cmd, tt.err)
+		if cmd.String() != tt.out || code != tt.code {
+			t.Errorf("%v: HandleError(%v) = %d, output %q; want %d, %q",
+				tt.args, tt.err, code, cmd.String(), tt.code, tt.out)
+		}
+	}
+}
+
+func TestHandleErrorExit(t *testing.T) {
+	o := cli.Options{Exit: &warnings.ExitPolicy{
+		Codes: map[string]int{"DATALOSS": 3}}}
+	err := warnings.List{Warnings: []error{&warnings.Warning{Code: "DATALOSS",
+		Msg: "1w"}}}
+	if code := o.HandleError(&command{}, err); code != 3 {
+		t.Errorf("HandleError() = %d; want 3", code)
+	}
+}
@@ -0,0 +1,52 @@
+package warnings
+
+// ComponentKey is the key of the attribute recording the component that
+// collected an error, see Collector.Component.
+const ComponentKey = "component"
+
+// A Component collects errors into a Collector on behalf of a subsystem
+// (such as "scheduler"), stamping each with an attribute with key
+// ComponentKey, so that reports of a large program identify which subsystem
+// produced what. Text output prefixes such errors with the component.
+type Component struct {
+	c    *Collector
+	name string
+}
+
+var _ Interface = &Component{}
+
+// Component returns a Component collecting into c under the given name.
+func (c *Collector) Component(name string) *Component {
+	return &Component{c, name}
+}
+
+// Component returns a child of p, collecting into the same Collector under
+// the name of p and the given name, joined by "/" (e.g. "scheduler/queue").
+func (p *Component) Component(name string) *Component {
+	return &Component{p.c, p.name + "/" + name}
+}
+
+// Name returns the name of p.
+func (p *Component) Name() string { return p.name }
+
+// Collect collects err into the Collector of p, as Collector.Collect.
+func (p *Component) Collect(err error, attrs ...Attr) error {
+	attrs = append(attrs[:len(attrs):len(attrs)], Field(ComponentKey, p.name))
+	return p.c.Collect(err, attrs...)
+}
+
+// Done ends collection of the Collector of p, as Collector.Done.
+func (p *Component) Done() error { return p.c.Done() }
+
+// ByComponent is a key function for GroupBy; it returns the component that
+// collected err (see Collector.Component), or "" if none.
+func ByComponent(err error) string {
+	attrs := AttrsOf(err)
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == ComponentKey {
+			s, _ := attrs[i].Value.(string)
+			return s
+		}
+	}
+	return ""
+}
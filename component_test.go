@@ -0,0 +1,32 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestComponent(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.FatalWithWarnings = true
+	sched := c.Component("scheduler")
+	queue := sched.Component("queue")
+	if queue.Name() != "scheduler/queue" {
+		t.Errorf("Name() = %q; want scheduler/queue", queue.Name())
+	}
+	c.Collect(&w.Warning{Msg: "1w"})
+	sched.Collect(&w.Warning{Code: "C2", Msg: "2w"}, w.Field("k", "v"))
+	queue.Collect(&w.Warning{Msg: "3w", Pos: w.Position{"f", 1, 0}})
+	err := queue.Done()
+	want := "warnings:\n1w\nscheduler: [C2] 2w\nscheduler/queue: f:1: 3w\n"
+	if err == nil || err.Error() != want {
+		t.Errorf("Done() = %q; want %q", err, want)
+	}
+	groups := err.(w.List).GroupBy(w.ByComponent)
+	if len(groups) != 3 || len(groups["scheduler"].Warnings) != 1 {
+		t.Errorf("GroupBy(ByComponent) = %v; want 3 groups", groups)
+	}
+	if attrs := w.AttrsOf(groups["scheduler"].Warnings[0]); len(attrs) != 2 {
+		t.Errorf("AttrsOf() = %v; want k and component", attrs)
+	}
+}
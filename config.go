@@ -0,0 +1,42 @@
+package warnings
+
+import "fmt"
+
+// Codes of the warnings returned by the constructors for configuration
+// parsers.
+const (
+	CodeUnknownKey    = "UNKNOWN_KEY"
+	CodeBadValue      = "BAD_VALUE"
+	CodeDeprecatedKey = "DEPRECATED_KEY"
+)
+
+// UnknownKey returns a Warning for an unknown key in the given section of a
+// configuration; section may be empty. Set its Pos to report the position:
+//
+//	w := warnings.UnknownKey("core", "editr")
+//	w.Pos = pos
+func UnknownKey(section, key string) *Warning {
+	msg := fmt.Sprintf("unknown key %q", key)
+	if section != "" {
+		msg += fmt.Sprintf(" in section %q", section)
+	}
+	return &Warning{Code: CodeUnknownKey, Msg: msg}
+}
+
+// BadValue returns a Warning for an invalid value of a key, caused by err
+// (e.g. as returned by strconv.Atoi); err may be nil.
+func BadValue(key, val string, err error) *Warning {
+	return &Warning{Code: CodeBadValue,
+		Msg: fmt.Sprintf("invalid value %q for key %q", val, key), Err: err}
+}
+
+// DeprecatedKey returns a Warning for the use of a deprecated key, with a hint
+// to use its replacement instead, if any.
+func DeprecatedKey(key, replacement string) *Warning {
+	w := &Warning{Code: CodeDeprecatedKey,
+		Msg: fmt.Sprintf("key %q is deprecated", key)}
+	if replacement != "" {
+		w.Hint = fmt.Sprintf("use %q instead", replacement)
+	}
+	return w
+}
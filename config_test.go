@@ -0,0 +1,41 @@
+package warnings_test
+
+import (
+	"errors"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var configTests = []struct {
+	w    *w.Warning
+	code string
+	msg  string
+	hint string
+}{
+	{w.UnknownKey("core", "editr"), w.CodeUnknownKey,
+		`unknown key "editr" in section "core"`, ""},
+	{w.UnknownKey("", "editr"), w.CodeUnknownKey, `unknown key "editr"`, ""},
+	{w.BadValue("port", "x", errors.New("not a number")), w.CodeBadValue,
+		`invalid value "x" for key "port": not a number`, ""},
+	{w.BadValue("port", "x", nil), w.CodeBadValue,
+		`invalid value "x" for key "port"`, ""},
+	{w.DeprecatedKey("color", "colour"), w.CodeDeprecatedKey,
+		`key "color" is deprecated`, `use "colour" instead`},
+	{w.DeprecatedKey("color", ""), w.CodeDeprecatedKey,
+		`key "color" is deprecated`, ""},
+}
+
+func TestConfigWarnings(t *testing.T) {
+	for _, tt := range configTests {
+		if tt.w.Code != tt.code || tt.w.Error() != tt.msg || tt.w.Hint != tt.hint {
+			t.Errorf("got %s %q (hint %q); want %s %q (hint %q)", tt.w.Code,
+				tt.w.Error(), tt.w.Hint, tt.code, tt.msg, tt.hint)
+		}
+	}
+	uk := w.UnknownKey("core", "editr")
+	uk.Pos = w.Position{Filename: "f", Line: 3}
+	if want := `f:3: unknown key "editr" in section "core"`; uk.Error() != want {
+		t.Errorf("Error() = %q; want %q", uk.Error(), want)
+	}
+}
@@ -0,0 +1,48 @@
+package warnings
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying c, so that functions deep in a
+// call chain can collect into it.
+func NewContext(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the Collector carried by ctx, if any.
+func FromContext(ctx context.Context) (*Collector, bool) {
+	c, ok := ctx.Value(contextKey{}).(*Collector)
+	return c, ok
+}
+
+// CollectFrom collects the errors received from ch until ch is closed or a
+// fatal error is collected, and returns the result of the last call to
+// Collect; if ctx is done first, it returns ctx.Err(). This lets producer
+// goroutines send errors without a reference to c. The buffer of ch bounds
+// the number of pending errors: when it is full, producers block until
+// CollectFrom catches up. As CollectFrom stops receiving on a fatal error,
+// producers should also select on a context that is canceled then:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	ch := make(chan error, 16)
+//	go produce(ctx, ch)
+//	if err := c.CollectFrom(ctx, ch); err != nil {
+//		return c.Done()
+//	}
+func (c *Collector) CollectFrom(ctx context.Context, ch <-chan error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := c.Collect(err); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package warnings_test
+
+import (
+	"context"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestContext(t *testing.T) {
+	if _, ok := w.FromContext(context.Background()); ok {
+		t.Errorf("FromContext(Background()) ok = true; want false")
+	}
+	c := w.NewCollector(isFatal)
+	ctx := w.NewContext(context.Background(), c)
+	if got, ok := w.FromContext(ctx); !ok || got != c {
+		t.Errorf("FromContext() = %p, %v; want %p, true", got, ok, c)
+	}
+}
+
+func TestCollectFrom(t *testing.T) {
+	tests := []struct {
+		errs  []error
+		fatal bool
+		n     int
+	}{
+		{nil, false, 0},
+		{[]error{warning("1w"), warning("2w")}, false, 2},
+		{[]error{warning("1w"), fatal("2f"), warning("3w")}, true, 1},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan error)
+		go func(errs []error) {
+			defer close(ch)
+			for _, err := range errs {
+				select {
+				case ch <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(tt.errs)
+		c := w.NewCollector(isFatal)
+		err := c.CollectFrom(ctx, ch)
+		cancel()
+		if (err != nil) != tt.fatal {
+			t.Errorf("CollectFrom(%v) = %v; want fatal %v", tt.errs, err, tt.fatal)
+		}
+		if got := len(c.Warnings()); got != tt.n {
+			t.Errorf("CollectFrom(%v) collected %d warnings; want %d",
+				tt.errs, got, tt.n)
+		}
+	}
+}
+
+func TestCollectFromCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := w.NewCollector(isFatal)
+	if err := c.CollectFrom(ctx, make(chan error)); err != context.Canceled {
+		t.Errorf("CollectFrom() = %v; want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,115 @@
+package warnings
+
+import "reflect"
+
+// components returns the errors combined in err if err is a multi-error, that
+// is the result of errors.Join or List.Joined, a hashicorp/go-multierror
+// *Error or an uber-go/multierr error. It returns nil otherwise, also for
+// other errors wrapping several errors (such as a *CausedError, or the result
+// of fmt.Errorf with several %w verbs), as their message differs from those
+// of the wrapped errors.
+func components(err error) []error {
+	switch e := err.(type) {
+	case interface{ Errors() []error }: // go.uber.org/multierr
+		return e.Errors()
+	case interface{ WrappedErrors() []error }: // github.com/hashicorp/go-multierror
+		return e.WrappedErrors()
+	case *joinedError:
+		return e.errs
+	case interface{ Unwrap() []error }:
+		if reflect.TypeOf(err) == joinType {
+			return e.Unwrap()
+		}
+	}
+	return nil
+}
+
+// Convert converts err into a List, using isFatal to distinguish between
+// warnings and fatal errors. Multi-errors (results of errors.Join and
+// List.Joined, and errors of the hashicorp/go-multierror and uber-go/multierr
+// packages) are flattened
+// into their components, which are then collected in order as by a Collector;
+// components following the first fatal error are thus dropped. A List is
+// returned unchanged.
+func Convert(err error, isFatal func(error) bool) List {
+	if l, ok := err.(List); ok {
+		return l
+	}
+	c := Collector{IsFatal: isFatal}
+	c.convert(err)
+	c.done = true
+	return c.l
+}
+
+func (c *Collector) convert(err error) {
+	if errs := components(err); errs != nil {
+		for _, err := range errs {
+			if c.done {
+				return
+			}
+			c.convert(err)
+		}
+		return
+	}
+	c.Collect(err)
+}
+
+// FromErrors returns a List holding errs, using isFatal (if nil, severity, as
+// for Collector.IsFatal) to distinguish between warnings and fatal errors.
+// Unlike Convert, it keeps all errors: fatal errors are added using AddFatal.
+// Nil entries are skipped.
+func FromErrors(errs []error, isFatal func(error) bool) List {
+	isFatal = orSeverity(isFatal)
+	var l List
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case isFatal(err):
+			l.AddFatal(err)
+		default:
+			l.AddWarning(err)
+		}
+	}
+	return l
+}
+
+// ToErrors returns the warnings in l, followed by the fatal errors if
+// withFatal is set, as a new slice.
+func (l List) ToErrors(withFatal bool) []error {
+	errs := append([]error(nil), l.Warnings...)
+	if withFatal {
+		errs = append(errs, l.FatalErrors()...)
+	}
+	return errs
+}
+
+// Joined returns an error with the same message as l, whose Unwrap method
+// returns all errors in l (the warnings followed by the fatal error), like the
+// errors returned by errors.Join. It returns nil if l holds no errors.
+func (l List) Joined() error {
+	if l.Fatal == nil && len(l.Warnings) == 0 {
+		return nil
+	}
+	return &joinedError{errs: l.ToErrors(true), msg: l.Error()}
+}
+
+// Messages returns the messages of the warnings in l. The fatal errors are
+// not included.
+func (l List) Messages() []string {
+	msgs := make([]string, 0, len(l.Warnings))
+	for _, err := range l.Warnings {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+// MessagesBySeverity returns the messages of the warnings in l per severity
+// (see SeverityOf). The fatal errors are not included.
+func (l List) MessagesBySeverity() map[Severity][]string {
+	m := make(map[Severity][]string)
+	for _, err := range l.Warnings {
+		s := SeverityOf(err)
+		m[s] = append(m[s], err.Error())
+	}
+	return m
+}
@@ -0,0 +1,26 @@
+//go:build go1.20
+// +build go1.20
+
+package warnings_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestConvertJoin(t *testing.T) {
+	err := errors.Join(warning("1w"), warning("2w"))
+	want := w.List{Warnings: []error{warning("1w"), warning("2w")}}
+	if got := w.Convert(err, isFatal); !reflect.DeepEqual(got, want) {
+		t.Errorf("Convert(%v) = %#v; want %#v", err, got, want)
+	}
+	err = fmt.Errorf("loading: %w, %w", warning("1w"), warning("2w"))
+	want = w.List{Fatal: err}
+	if got := w.Convert(err, isFatal); !reflect.DeepEqual(got, want) {
+		t.Errorf("Convert(%v) = %#v; want %#v", err, got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+// wrapping mimics the result of fmt.Errorf with several %w verbs.
+type wrapping []error
+
+func (e wrapping) Error() string   { return "wrapping" }
+func (e wrapping) Unwrap() []error { return e }
+
+// joined returns errs joined as by errors.Join.
+func joined(errs ...error) error { return w.List{Warnings: errs}.Joined() }
+
+// multierror mimics a hashicorp/go-multierror *Error.
+type multierror []error
+
+func (m multierror) Error() string          { return "multierror" }
+func (m multierror) WrappedErrors() []error { return m }
+
+// multierr mimics an uber-go/multierr error.
+type multierr []error
+
+func (m multierr) Error() string   { return "multierr" }
+func (m multierr) Errors() []error { return m }
+
+func TestConvert(t *testing.T) {
+	f1, f2 := fatal("3f"), fatal("5f")
+	tests := []struct {
+		err  error
+		want w.List
+	}{
+		{nil, w.List{}},
+		{warning("1w"), w.List{Warnings: []error{warning("1w")}}},
+		{f1, w.List{Fatal: f1}},
+		{joined(warning("1w"), warning("2w")),
+			w.List{Warnings: []error{warning("1w"), warning("2w")}}},
+		{wrapping{warning("1w"), warning("2w")},
+			w.List{Fatal: wrapping{warning("1w"), warning("2w")}}},
+		{&w.CausedError{Err: warning("1w"), Cause: f1},
+			w.List{Fatal: &w.CausedError{Err: warning("1w"), Cause: f1}}},
+		{multierror{warning("1w"), joined(warning("2w"), f1), f2},
+			w.List{Warnings: []error{warning("1w"), warning("2w")}, Fatal: f1}},
+		{multierr{warning("1w"), nil, f1},
+			w.List{Warnings: []error{warning("1w")}, Fatal: f1}},
+		{w.List{Fatal: f2}, w.List{Fatal: f2}},
+	}
+	for _, tt := range tests {
+		if got := w.Convert(tt.err, isFatal); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Convert(%v) = %#v; want %#v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestJoined(t *testing.T) {
+	f := fatal("2f")
+	l := w.List{Warnings: []error{warning("1w")}, Fatal: f}
+	err := l.Joined()
+	if err.Error() != l.Error() {
+		t.Errorf("Joined().Error() = %q; want %q", err.Error(), l.Error())
+	}
+	u, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Joined() = %#v; want Unwrap() []error", err)
+	}
+	if want := []error{warning("1w"), f}; !reflect.DeepEqual(u.Unwrap(), want) {
+		t.Errorf("Joined().Unwrap() = %v; want %v", u.Unwrap(), want)
+	}
+	if got := w.Convert(err, isFatal); !reflect.DeepEqual(got, l) {
+		t.Errorf("Convert(Joined()) = %v; want %v", got, l)
+	}
+	if err := (w.List{}).Joined(); err != nil {
+		t.Errorf("List{}.Joined() = %v; want nil", err)
+	}
+	// Is and As are called directly, as errors.Is and errors.As use Unwrap
+	// instead as of Go 1.20.
+	if is, ok := err.(interface{ Is(error) bool }); !ok || !is.Is(f) {
+		t.Errorf("Joined().Is(fatal) = false; want true")
+	}
+	var ww warn
+	if as, ok := err.(interface{ As(interface{}) bool }); !ok || !as.As(&ww) || ww != "1w" {
+		t.Errorf("Joined().As(&warn) = %q; want 1w", ww)
+	}
+}
+
+func TestFromErrors(t *testing.T) {
+	f1, f2 := fatal("2f"), fatal("4f")
+	l := w.FromErrors([]error{warning("1w"), f1, nil, warning("3w"), f2}, isFatal)
+	want := w.List{Warnings: []error{warning("1w"), warning("3w")}, Fatal: f1,
+		Fatals: []error{f1, f2}}
+	if !reflect.DeepEqual(l, want) {
+		t.Errorf("FromErrors() = %#v; want %#v", l, want)
+	}
+	if got := l.ToErrors(false); !reflect.DeepEqual(got, want.Warnings) {
+		t.Errorf("ToErrors(false) = %v; want %v", got, want.Warnings)
+	}
+	all := []error{warning("1w"), warning("3w"), f1, f2}
+	if got := l.ToErrors(true); !reflect.DeepEqual(got, all) {
+		t.Errorf("ToErrors(true) = %v; want %v", got, all)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	l := w.List{
+		Warnings: []error{
+			warning("1w"),
+			&w.Warning{Severity: w.Error, Msg: "2w"},
+			&w.Warning{Severity: w.Info, Msg: "3w", Pos: w.Position{Line: 1}},
+			&w.Warning{Msg: "4w"},
+		},
+		Fatal: fatal("5f"),
+	}
+	want := []string{"1w", "2w", "1: 3w", "4w"}
+	if got := l.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages() = %q; want %q", got, want)
+	}
+	wantBySev := map[w.Severity][]string{w.Info: {"1: 3w"}, w.Warn: {"1w", "4w"},
+		w.Error: {"2w"}}
+	if got := l.MessagesBySeverity(); !reflect.DeepEqual(got, wantBySev) {
+		t.Errorf("MessagesBySeverity() = %q; want %q", got, wantBySev)
+	}
+	if got := (w.List{}).Messages(); len(got) != 0 {
+		t.Errorf("empty Messages() = %q; want none", got)
+	}
+}
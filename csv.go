@@ -0,0 +1,48 @@
+package warnings
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{"severity", "code", "file", "line", "message", "count"}
+
+// WriteCSV writes l to w as CSV, with a header row followed by one row per
+// warning and a final row for the fatal error, if any. The columns are
+// severity ("fatal" for the fatal error), code, file, line, message (without
+// the position) and count (see Warning.Count).
+func (l List) WriteCSV(w io.Writer) error {
+	return l.writeCSV(w, ',')
+}
+
+// WriteTSV is like WriteCSV, but separates fields by tabs.
+func (l List) WriteTSV(w io.Writer) error {
+	return l.writeCSV(w, '\t')
+}
+
+func (l List) writeCSV(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write(csvHeader)
+	for _, err := range l.Warnings {
+		cw.Write(csvRecord(err, SeverityOf(err).String()))
+	}
+	for _, err := range l.FatalErrors() {
+		cw.Write(csvRecord(err, "fatal"))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRecord(err error, severity string) []string {
+	var file, line string
+	if w, ok := AsWarning(err); ok {
+		file = w.Pos.Filename
+		if w.Pos.IsValid() {
+			line = strconv.Itoa(w.Pos.Line)
+		}
+	}
+	return []string{severity, codeOf(err), file, line, messageOf(err),
+		strconv.Itoa(countOf(err))}
+}
@@ -0,0 +1,45 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var csvList = w.List{
+	Warnings: []error{
+		&w.Warning{Code: "C1", Msg: "a, b", Count: 3,
+			Pos: w.Position{Filename: "f.cfg", Line: 2, Column: 1}},
+		warning("1w"),
+	},
+	Fatal: fatal("2f"),
+}
+
+func TestWriteCSV(t *testing.T) {
+	want := "severity,code,file,line,message,count\n" +
+		"warning,C1,f.cfg,2,\"a, b\",3\n" +
+		"warning,,,,1w,1\n" +
+		"fatal,,,,2f,1\n"
+	b := bytes.NewBuffer(nil)
+	if err := csvList.WriteCSV(b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != want {
+		t.Errorf("WriteCSV() = %q; want %q", b, want)
+	}
+}
+
+func TestWriteTSV(t *testing.T) {
+	want := "severity\tcode\tfile\tline\tmessage\tcount\n" +
+		"warning\tC1\tf.cfg\t2\ta, b\t3\n" +
+		"warning\t\t\t\t1w\t1\n" +
+		"fatal\t\t\t\t2f\t1\n"
+	b := bytes.NewBuffer(nil)
+	if err := csvList.WriteTSV(b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != want {
+		t.Errorf("WriteTSV() = %q; want %q", b, want)
+	}
+}
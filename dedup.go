@@ -0,0 +1,27 @@
+package warnings
+
+import "time"
+
+// dedup reports whether the warning err is to be dropped because an
+// identical one was kept less than c.DedupWindow ago, and otherwise records
+// err as kept.
+func (c *Collector) dedup(err error) bool {
+	now := c.now()
+	fp := Fingerprint(err)
+	if t, ok := c.seen[fp]; ok && now.Sub(t) < c.DedupWindow {
+		return true
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]time.Time)
+	}
+	if len(c.seen) >= c.seenPrune {
+		for fp, t := range c.seen {
+			if now.Sub(t) >= c.DedupWindow {
+				delete(c.seen, fp)
+			}
+		}
+		c.seenPrune = 2*len(c.seen) + 64
+	}
+	c.seen[fp] = now
+	return false
+}
@@ -0,0 +1,35 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestDedupWindow(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := w.NewCollector(isFatal)
+	c.DedupWindow = time.Minute
+	c.Now = func() time.Time { return now }
+	steps := []struct {
+		after time.Duration
+		err   error
+	}{
+		{0, warning("1w")},
+		{0, warning("2w")},
+		{30 * time.Second, warning("1w")}, // dropped
+		{29 * time.Second, warning("1w")}, // dropped
+		{time.Second, warning("1w")},      // a minute after the first
+		{0, warning("2w")},
+	}
+	for _, s := range steps {
+		now = now.Add(s.after)
+		c.Collect(s.err)
+	}
+	want := []error{warning("1w"), warning("2w"), warning("1w"), warning("2w")}
+	if got := w.WarningsOnly(c.Done()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Done() warnings = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package warnings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// A Deprecation is a warning about the use of a deprecated feature, which
+// will stop being supported at its sunset (a version, a date, or both). Use
+// Sunset to make deprecations past their sunset fatal.
+type Deprecation struct {
+	Feature     string    // the deprecated feature, e.g. `key "color"`
+	Introduced  string    // version deprecating the feature; optional
+	Sunset      string    // version removing the feature; optional
+	SunsetDate  time.Time // date the feature is removed; optional
+	Replacement string    // what to use instead; optional
+}
+
+// Error implements the error interface.
+func (d *Deprecation) Error() string {
+	s := d.Feature + " is deprecated"
+	if d.Introduced != "" {
+		s += " since " + d.Introduced
+	}
+	switch {
+	case d.Sunset != "" && !d.SunsetDate.IsZero():
+		s += fmt.Sprintf(" and will be removed in %s (%s)", d.Sunset,
+			d.SunsetDate.Format("2006-01-02"))
+	case d.Sunset != "":
+		s += " and will be removed in " + d.Sunset
+	case !d.SunsetDate.IsZero():
+		s += " and will be removed on " + d.SunsetDate.Format("2006-01-02")
+	}
+	if d.Replacement != "" {
+		s += "; use " + d.Replacement + " instead"
+	}
+	return s
+}
+
+// PastSunset reports whether d is past its sunset for the given version
+// and time: whether version is at or after d.Sunset, or now is at or after
+// d.SunsetDate. An empty version or zero time is not compared.
+func (d *Deprecation) PastSunset(version string, now time.Time) bool {
+	if d.Sunset != "" && version != "" && compareVersions(version, d.Sunset) >= 0 {
+		return true
+	}
+	return !d.SunsetDate.IsZero() && !now.IsZero() && !now.Before(d.SunsetDate)
+}
+
+// Sunset returns an IsFatal function treating deprecations (errors wrapping
+// a *Deprecation) past their sunset for the given version and the time of
+// classification as fatal; other errors are classified by isFatal (if nil,
+// by severity, as for Collector.IsFatal). Now, if set, is used instead of
+// time.Now, e.g. for testing.
+func Sunset(isFatal func(error) bool, version string, now func() time.Time) func(error) bool {
+	isFatal = orSeverity(isFatal)
+	if now == nil {
+		now = time.Now
+	}
+	return func(err error) bool {
+		var d *Deprecation
+		if errors.As(err, &d) && d.PastSunset(version, now()) {
+			return true
+		}
+		return isFatal(err)
+	}
+}
@@ -0,0 +1,88 @@
+package warnings_test
+
+import (
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var (
+	day1 = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 = day1.AddDate(0, 0, 1)
+)
+
+var deprecationMsgTests = []struct {
+	d    w.Deprecation
+	want string
+}{
+	{w.Deprecation{Feature: "f"}, "f is deprecated"},
+	{w.Deprecation{Feature: "f", Introduced: "v1.2", Sunset: "v2",
+		Replacement: "g"},
+		"f is deprecated since v1.2 and will be removed in v2; use g instead"},
+	{w.Deprecation{Feature: "f", SunsetDate: day1},
+		"f is deprecated and will be removed on 2030-01-01"},
+	{w.Deprecation{Feature: "f", Sunset: "v2", SunsetDate: day1},
+		"f is deprecated and will be removed in v2 (2030-01-01)"},
+}
+
+func TestDeprecationError(t *testing.T) {
+	for _, tt := range deprecationMsgTests {
+		if got := tt.d.Error(); got != tt.want {
+			t.Errorf("Error() = %q; want %q", got, tt.want)
+		}
+	}
+}
+
+var sunsetTests = []struct {
+	d       w.Deprecation
+	version string
+	now     time.Time
+	want    bool
+}{
+	{w.Deprecation{Sunset: "v2.0"}, "v1.9.3", time.Time{}, false},
+	{w.Deprecation{Sunset: "v2.0"}, "v2.0.0-rc1", time.Time{}, false},
+	{w.Deprecation{Sunset: "v2.0"}, "2", time.Time{}, true},
+	{w.Deprecation{Sunset: "v2.0"}, "v10.1", time.Time{}, true},
+	{w.Deprecation{Sunset: "v2.0"}, "", day2, false},
+	{w.Deprecation{SunsetDate: day2}, "v3", day1, false},
+	{w.Deprecation{SunsetDate: day2}, "", day2, true},
+	{w.Deprecation{}, "v3", day2, false},
+}
+
+func TestSunset(t *testing.T) {
+	for _, tt := range sunsetTests {
+		d := tt.d
+		if got := d.PastSunset(tt.version, tt.now); got != tt.want {
+			t.Errorf("%+v.PastSunset(%q, %v) = %v; want %v", tt.d, tt.version,
+				tt.now, got, tt.want)
+		}
+		now := tt.now
+		isFatal := w.Sunset(func(error) bool { return false }, tt.version,
+			func() time.Time { return now })
+		if got := isFatal(&d); got != tt.want {
+			t.Errorf("Sunset(%q, %v)(%+v) = %v; want %v", tt.version, tt.now,
+				tt.d, got, tt.want)
+		}
+	}
+	if !w.Sunset(isFatal, "v1", nil)(fatal("1f")) {
+		t.Errorf("Sunset() doesn't defer to isFatal")
+	}
+}
+
+func TestSunsetClock(t *testing.T) {
+	now := day1
+	isFatal := w.Sunset(nil, "", func() time.Time { return now })
+	d := &w.Deprecation{SunsetDate: day2}
+	if isFatal(d) {
+		t.Errorf("Sunset()(%v) = true before the sunset date", d)
+	}
+	now = day2
+	if !isFatal(d) {
+		t.Errorf("Sunset()(%v) = false at the sunset date", d)
+	}
+	past := &w.Deprecation{SunsetDate: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if !w.Sunset(nil, "", nil)(past) {
+		t.Errorf("Sunset(nil clock)(%v) = false; want true", past)
+	}
+}
@@ -0,0 +1,107 @@
+package warnings
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Deterministic is a Renderer wrapping another one to produce output that is
+// stable across runs, for snapshot tests and fuzzers comparing outputs: the
+// warnings (and fatal errors) are sorted by position, code and message, their
+// messages and hints are passed through Normalize, and time.Time attribute
+// values are replaced by Time.
+type Deterministic struct {
+	// Renderer is the Renderer wrapped; if nil, Text{} is used.
+	Renderer Renderer
+	// Normalize, if set, rewrites messages and hints, e.g. to remove
+	// addresses (see NormalizeAddresses) or temporary file names.
+	Normalize func(s string) string
+	// Time replaces time.Time attribute values; the zero value is used by
+	// default.
+	Time time.Time
+}
+
+// Render implements Renderer.
+func (d Deterministic) Render(w io.Writer, l List) error {
+	r := d.Renderer
+	if r == nil {
+		r = Text{}
+	}
+	return r.Render(w, sortList(l.Map(d.normalize)))
+}
+
+// normalize returns err as a *Warning normalized as described for
+// Deterministic.
+func (d Deterministic) normalize(err error) error {
+	w := copyWarning(err)
+	w.Msg, w.Err = w.message(), nil
+	if d.Normalize != nil {
+		w.Msg, w.Hint = d.Normalize(w.Msg), d.Normalize(w.Hint)
+	}
+	if len(w.Attrs) > 0 {
+		attrs := make([]Attr, len(w.Attrs))
+		for i, a := range w.Attrs {
+			if _, ok := a.Value.(time.Time); ok {
+				a.Value = d.Time
+			}
+			attrs[i] = a
+		}
+		w.Attrs = attrs
+	}
+	return w
+}
+
+// sortList sorts the warnings and fatal errors of l, including those in
+// sections, in place.
+func sortList(l List) List {
+	for _, err := range l.Warnings {
+		if s, ok := err.(*Section); ok {
+			s.List = sortList(s.List)
+		}
+	}
+	sortErrors(l.Warnings)
+	if len(l.Fatals) > 0 {
+		sortErrors(l.Fatals)
+		l.Fatal = l.Fatals[0]
+	}
+	return l
+}
+
+// sortErrors sorts errs by position, code and message.
+func sortErrors(errs []error) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return lessError(errs[i], errs[j])
+	})
+}
+
+func lessError(a, b error) bool {
+	var p, q Position
+	if w, ok := AsWarning(a); ok {
+		p = w.Pos
+	}
+	if w, ok := AsWarning(b); ok {
+		q = w.Pos
+	}
+	switch {
+	case p.Filename != q.Filename:
+		return p.Filename < q.Filename
+	case p.Line != q.Line:
+		return p.Line < q.Line
+	case p.Column != q.Column:
+		return p.Column < q.Column
+	case codeOf(a) != codeOf(b):
+		return codeOf(a) < codeOf(b)
+	}
+	return messageOf(a) < messageOf(b)
+}
+
+// addressRE matches hexadecimal addresses such as 0xc000012345.
+var addressRE = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+// NormalizeAddresses replaces hexadecimal addresses such as 0xc000012345 in s
+// by "0x?". It is meant for use as Deterministic.Normalize.
+func NormalizeAddresses(s string) string {
+	return addressRE.ReplaceAllString(s, "0x?")
+}
@@ -0,0 +1,47 @@
+package warnings_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestDeterministic(t *testing.T) {
+	render := func(l w.List, d w.Deterministic) string {
+		b := bytes.NewBuffer(nil)
+		if err := d.Render(b, l); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+	run := func(addr string, at time.Time) w.List {
+		l := w.List{Warnings: []error{
+			&w.Warning{Msg: "b", Pos: w.Position{"f", 2, 0}},
+			&w.Warning{Code: "C2", Msg: "at " + addr, Err: errors.New("cause")},
+			&w.Warning{Code: "C1", Msg: "a", Attrs: []w.Attr{w.Field("t", at)}},
+			warning("plain " + addr),
+		}}
+		l.AddFatal(warning("2f"))
+		l.AddFatal(warning("1f"))
+		l.AddSection("s", w.List{Warnings: []error{warning("y"), warning("x")}})
+		return l
+	}
+	l1 := run("0xc000012345", time.Unix(1, 0))
+	l2 := run("0xc0000abcde", time.Unix(2, 0))
+	d := w.Deterministic{Normalize: w.NormalizeAddresses}
+	want := "fatal:\n1f\n2f\nwarnings:\nplain 0x?\ns:\n  warnings:\n  x\n  y\n" +
+		"[C1] a\n[C2] at 0x?: cause\nf:2: b\n"
+	if got := render(l1, d); got != want {
+		t.Errorf("Render() = %q; want %q", got, want)
+	}
+	nd := w.Deterministic{Renderer: w.NDJSON{}, Normalize: w.NormalizeAddresses}
+	if r1, r2 := render(l1, nd), render(l2, nd); r1 != r2 {
+		t.Errorf("Render() differs between runs:\n%s\n%s", r1, r2)
+	}
+	if got := l1.Warnings[0].Error(); got != "f:2: b" {
+		t.Errorf("Render() modified l: first warning is %q", got)
+	}
+}
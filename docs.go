@@ -0,0 +1,28 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// A DocRegistry maps warning codes to the URLs of their documentation, for
+// rendering by Text (see Text.Docs).
+type DocRegistry map[string]string
+
+// writeDocs writes a "more info" line to b for each distinct code of the
+// errors in l with documentation in r, in order of first occurrence.
+func (r DocRegistry) writeDocs(b *bytes.Buffer, l List) {
+	if len(r) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, errs := range [][]error{l.FatalErrors(), l.Warnings} {
+		for _, err := range errs {
+			code := codeOf(err)
+			if url, ok := r[code]; ok && code != "" && !seen[code] {
+				seen[code] = true
+				fmt.Fprintf(b, "[%s] more info: %s\n", code, url)
+			}
+		}
+	}
+}
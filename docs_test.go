@@ -0,0 +1,40 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestTextDocs(t *testing.T) {
+	docs := w.DocRegistry{
+		"C1": "https://example.com/C1",
+		"C2": "https://example.com/C2",
+	}
+	l := w.List{Warnings: []error{
+		&w.Warning{Code: "C2", Msg: "a"},
+		&w.Warning{Code: "C3", Msg: "b"},
+		&w.Warning{Code: "C2", Msg: "c"},
+		warning("d"),
+	}, Fatal: &w.Warning{Code: "C1", Msg: "e"}}
+	tests := []struct {
+		text w.Text
+		want string
+	}{
+		{w.Text{Docs: docs}, "fatal:\n[C1] e\nwarnings:\n[C2] a\n[C3] b\n[C2] c\nd\n" +
+			"[C1] more info: https://example.com/C1\n" +
+			"[C2] more info: https://example.com/C2\n"},
+		{w.Text{Docs: docs, Compact: true, NoTrailingNewline: true},
+			"fatal: [C1] e (warnings: [C2] a; [C3] b; [C2] c; d)"},
+	}
+	for _, tt := range tests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.text.Render(b, l); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Render() = %q; want %q", b, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package warnings
+
+// Escalate adds a rule making the n-th warning with the given code a fatal
+// error, for warnings that are fine occasionally but indicate a systemic
+// failure in bulk. Occurrences are counted taking Warning.Count into account;
+// ignored warnings are not counted. If n <= 0, the rule for code is removed.
+func (c *Collector) Escalate(code string, n int) {
+	if n <= 0 {
+		delete(c.escalations, code)
+		return
+	}
+	if c.escalations == nil {
+		c.escalations = make(map[string]*escalation)
+	}
+	c.escalations[code] = &escalation{n: n}
+}
+
+// escalation is the state of an escalation rule.
+type escalation struct {
+	n    int // occurrences at which a warning becomes fatal
+	seen int // occurrences so far
+}
+
+// escalate counts the warning err and reports whether it is to be escalated
+// to a fatal error.
+func (c *Collector) escalate(err error) bool {
+	code := codeOf(err)
+	if code == "" {
+		return false
+	}
+	e, ok := c.escalations[code]
+	if !ok {
+		return false
+	}
+	e.seen += countOf(err)
+	return e.seen >= e.n
+}
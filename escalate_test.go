@@ -0,0 +1,44 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestEscalate(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.Escalate("IO", 3)
+	c.Escalate("NET", 1)
+	c.Escalate("NET", 0)
+	c.Ignore = func(err error) bool { return err.Error() == "ignored" }
+	errs := []error{
+		&w.Warning{Code: "IO", Msg: "retry 1"},
+		&w.Warning{Code: "NET", Msg: "net"},
+		&w.Warning{Code: "IO", Msg: "ignored"},
+		warning("1w"),
+		&w.Warning{Code: "IO", Msg: "retry 2"},
+	}
+	for _, err := range errs {
+		if got := c.Collect(err); got != nil {
+			t.Fatalf("Collect(%v) = %v; want nil", err, got)
+		}
+	}
+	last := &w.Warning{Code: "IO", Msg: "retry 3"}
+	if err := c.Collect(last); err == nil {
+		t.Fatalf("Collect(%v) = nil; want fatal", last)
+	}
+	fatal, warns := w.Split(c.Done())
+	if fatal != last || len(warns) != 4 {
+		t.Errorf("Done() = %v, %v; want %v and 4 warnings", fatal, warns, last)
+	}
+}
+
+func TestEscalateCount(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.Escalate("IO", 3)
+	c.Collect(&w.Warning{Code: "IO", Msg: "retry", Count: 2})
+	if err := c.Collect(&w.Warning{Code: "IO", Msg: "retry"}); err == nil {
+		t.Errorf("Collect() = nil; want fatal after 3 occurrences")
+	}
+}
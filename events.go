@@ -0,0 +1,56 @@
+package warnings
+
+// eventsBuffer is the capacity of the channel returned by Collector.Events.
+const eventsBuffer = 64
+
+// An Event reports a single error collected by a Collector, to Collector.Hook
+// and on the channel returned by Collector.Events.
+type Event struct {
+	Err   error
+	Fatal bool   // whether Err is the fatal error
+	Phase string // name of the current phase, if any
+}
+
+// Events returns a channel on which an Event is delivered for each error
+// collected from now on, allowing errors to be observed (e.g. displayed) while
+// collection is still in progress. The channel is closed after the first
+// fatal error or when Done is called.
+//
+// The channel has a small buffer. When the buffer is full, Collect blocks
+// until the receiver catches up, so that no event is lost; the receiver must
+// therefore keep receiving until the channel is closed.
+func (c *Collector) Events() <-chan Event {
+	if c.events == nil {
+		c.events = make(chan Event, eventsBuffer)
+		if c.done {
+			close(c.events)
+		}
+	}
+	return c.events
+}
+
+func (c *Collector) emit(err error, fatal bool) {
+	if c.events == nil && c.Hook == nil {
+		return
+	}
+	e := Event{Err: err, Fatal: fatal}
+	if len(c.phases) > 0 {
+		e.Phase = c.phases[len(c.phases)-1].Name
+	}
+	if c.Hook != nil {
+		c.Hook(e)
+	}
+	if c.events == nil {
+		return
+	}
+	c.events <- e
+	if fatal {
+		close(c.events)
+	}
+}
+
+func (c *Collector) closeEvents() {
+	if c.events != nil {
+		close(c.events)
+	}
+}
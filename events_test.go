@@ -0,0 +1,38 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestEvents(t *testing.T) {
+	for _, tt := range collectorTests {
+		c := tt.collector
+		events := c.Events()
+		got := make(chan []w.Event)
+		go func() {
+			var es []w.Event
+			for e := range events {
+				es = append(es, e)
+			}
+			got <- es
+		}()
+		var want []w.Event
+		for _, warn := range tt.warnings {
+			c.Collect(warn)
+			if warn != nil {
+				want = append(want, w.Event{Err: warn})
+			}
+		}
+		if tt.fatal != nil {
+			c.Collect(tt.fatal)
+			want = append(want, w.Event{Err: tt.fatal, Fatal: true})
+		}
+		c.Done()
+		if es := <-got; !reflect.DeepEqual(es, want) {
+			t.Errorf("Events() = %v; want %v", es, want)
+		}
+	}
+}
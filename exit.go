@@ -0,0 +1,52 @@
+package warnings
+
+// An ExitPolicy maps the errors a program ends with to its exit status, for
+// tooling that branches on the exit status of a program.
+type ExitPolicy struct {
+	// Codes maps warning codes to exit statuses. It applies to the fatal
+	// error as well.
+	Codes map[string]int
+	// Severities maps severities to exit statuses, for warnings whose code
+	// isn't in Codes; for other severities, the ExitCode of their
+	// SeverityLevel applies.
+	Severities map[Severity]int
+	// Fatal is the exit status for a fatal error whose code isn't in Codes;
+	// 0 means 1.
+	Fatal int
+}
+
+// ExitCode returns the exit status for a program ending with err: the highest
+// exit status that p maps any of the errors in err to, or 0 if there is none.
+func (p *ExitPolicy) ExitCode(err error) int {
+	fatal, warns := Split(err)
+	code := 0
+	for _, err := range warns {
+		if c := p.warningCode(err); c > code {
+			code = c
+		}
+	}
+	if fatal != nil {
+		c, ok := p.Codes[codeOf(fatal)]
+		if !ok {
+			c = p.Fatal
+			if c == 0 {
+				c = 1
+			}
+		}
+		if c > code {
+			code = c
+		}
+	}
+	return code
+}
+
+func (p *ExitPolicy) warningCode(err error) int {
+	if c, ok := p.Codes[codeOf(err)]; ok {
+		return c
+	}
+	s := SeverityOf(err)
+	if c, ok := p.Severities[s]; ok {
+		return c
+	}
+	return s.Level().ExitCode
+}
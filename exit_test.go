@@ -0,0 +1,42 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var exitPolicy = w.ExitPolicy{
+	Codes:      map[string]int{"DEPRECATED": 0, "DATALOSS": 3, "ABORT": 4},
+	Severities: map[w.Severity]int{w.Error: 2},
+}
+
+var exitPolicyTests = []struct {
+	err  error
+	want int
+}{
+	{nil, 0},
+	{w.List{Warnings: []error{warning("1w")}}, 0},
+	{w.List{Warnings: []error{&w.Warning{Severity: w.Error, Msg: "1w"}}}, 2},
+	{w.List{Warnings: []error{
+		&w.Warning{Code: "DEPRECATED", Severity: w.Error, Msg: "1w"}}}, 0},
+	{w.List{Warnings: []error{
+		&w.Warning{Code: "DATALOSS", Msg: "1w"},
+		&w.Warning{Severity: w.Error, Msg: "2w"}}}, 3},
+	{fatal("1f"), 1},
+	{&w.Warning{Code: "ABORT", Msg: "1f"}, 4},
+	{w.List{Warnings: []error{&w.Warning{Code: "DATALOSS", Msg: "1w"}},
+		Fatal: fatal("2f")}, 3},
+}
+
+func TestExitPolicy(t *testing.T) {
+	for _, tt := range exitPolicyTests {
+		if got := exitPolicy.ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d; want %d", tt.err, got, tt.want)
+		}
+	}
+	p := w.ExitPolicy{Fatal: 5}
+	if got := p.ExitCode(fatal("1f")); got != 5 {
+		t.Errorf("ExitCode() with Fatal 5 = %d; want 5", got)
+	}
+}
@@ -0,0 +1,63 @@
+package warnings
+
+import "regexp"
+
+// A Filter reports whether a warning is to be kept, e.g. when rendering.
+type Filter func(err error) bool
+
+// Include returns a Filter keeping warnings whose message matches re.
+func Include(re *regexp.Regexp) Filter {
+	return func(err error) bool { return re.MatchString(err.Error()) }
+}
+
+// Exclude returns a Filter dropping warnings whose message matches re.
+func Exclude(re *regexp.Regexp) Filter {
+	return func(err error) bool { return !re.MatchString(err.Error()) }
+}
+
+// MinSeverity returns a Filter keeping warnings with severity s or higher
+// (see SeverityOf).
+func MinSeverity(s Severity) Filter {
+	return func(err error) bool { return SeverityOf(err) >= s }
+}
+
+// Filter returns a copy of l with only the warnings kept by all filters. The
+// fatal error is always kept. Warnings dropped are counted in Omitted. l
+// itself is not modified.
+func (l List) Filter(filters ...Filter) List {
+	if len(filters) == 0 {
+		return l
+	}
+	fl := l
+	fl.Warnings = nil
+outer:
+	for _, err := range l.Warnings {
+		for _, keep := range filters {
+			if !keep(err) {
+				fl.Omitted += countOf(err)
+				continue outer
+			}
+		}
+		fl.Warnings = append(fl.Warnings, err)
+	}
+	return fl
+}
+
+// Map returns a copy of l with fn applied to each warning and fatal error,
+// e.g. to rewrite their messages; errors mapped to nil are dropped. Sections
+// are mapped recursively, rather than passed to fn. l itself is not modified.
+func (l List) Map(fn func(error) error) List {
+	ml := l
+	ml.Warnings, ml.Fatal, ml.Fatals = nil, nil, nil
+	for _, err := range l.Warnings {
+		if s, ok := err.(*Section); ok {
+			ml.AddWarning(&Section{Label: s.Label, List: s.List.Map(fn)})
+			continue
+		}
+		ml.AddWarning(fn(err))
+	}
+	for _, err := range l.FatalErrors() {
+		ml.AddFatal(fn(err))
+	}
+	return ml
+}
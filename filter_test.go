@@ -0,0 +1,82 @@
+package warnings_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var (
+	filterInfo = &w.Warning{Severity: w.Info, Msg: "info: disk"}
+	filterErr  = &w.Warning{Severity: w.Error, Msg: "error: disk", Count: 2}
+	filterList = w.List{
+		Warnings: []error{filterInfo, warning("warn: net"), filterErr},
+		Fatal:    fatal("fatal: disk"),
+	}
+)
+
+var filterTests = []struct {
+	filters []w.Filter
+	warns   []error
+	omitted int
+}{
+	{nil, filterList.Warnings, 0},
+	{[]w.Filter{w.MinSeverity(w.Warn)}, []error{warning("warn: net"), filterErr}, 1},
+	{[]w.Filter{w.Include(regexp.MustCompile("disk"))}, []error{filterInfo, filterErr}, 1},
+	{[]w.Filter{w.Exclude(regexp.MustCompile("disk"))}, []error{warning("warn: net")}, 3},
+	{[]w.Filter{w.MinSeverity(w.Warn), w.Include(regexp.MustCompile("disk"))},
+		[]error{filterErr}, 2},
+}
+
+func TestFilter(t *testing.T) {
+	for i, tt := range filterTests {
+		got := filterList.Filter(tt.filters...)
+		if !reflect.DeepEqual(got.Warnings, tt.warns) || got.Omitted != tt.omitted ||
+			got.Fatal != filterList.Fatal {
+			t.Errorf("%d: Filter() = %v (%d omitted); want %v (%d omitted)", i,
+				got.Warnings, got.Omitted, tt.warns, tt.omitted)
+		}
+	}
+	if len(filterList.Warnings) != 3 || filterList.Omitted != 0 {
+		t.Errorf("Filter modified the List")
+	}
+}
+
+func TestTextFilters(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	r := w.Text{Filters: []w.Filter{w.MinSeverity(w.Error)}}
+	if err := r.Render(b, filterList); err != nil {
+		t.Fatal(err)
+	}
+	want := "fatal:\nfatal: disk\nwarnings:\nerror: disk (1 more times)\n" +
+		"(2 more warning(s) omitted)\n"
+	if b.String() != want {
+		t.Errorf("Render() = %q; want %q", b, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	l := w.List{
+		Warnings: []error{warning("1w"), warning("drop"),
+			&w.Section{Label: "s", List: w.List{Warnings: []error{warning("2w")}}}},
+		Fatal: fatal("3f"),
+	}
+	before := l.Error()
+	ml := l.Map(func(err error) error {
+		if err.Error() == "drop" {
+			return nil
+		}
+		return fmt.Errorf("f: %v", err)
+	})
+	want := "fatal:\nf: 3f\nwarnings:\nf: 1w\ns:\n  warning:\n  f: 2w\n"
+	if ml.Error() != want {
+		t.Errorf("Map() = %q; want %q", ml.Error(), want)
+	}
+	if l.Error() != before {
+		t.Errorf("Map modified the List: %q", l.Error())
+	}
+}
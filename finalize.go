@@ -0,0 +1,63 @@
+package warnings
+
+import "fmt"
+
+// A FinalizeFunc inspects the List collected by a Collector once collection
+// ends, and returns a fatal error to end it with, or nil; see
+// Collector.Finalize.
+type FinalizeFunc func(l List) error
+
+// MaxWarningRatio returns a FinalizeFunc for quality gates failing if more
+// than ratio (e.g. 0.01 for 1%) of total records warned, counting each
+// warning as one record.
+func MaxWarningRatio(ratio float64, total int) FinalizeFunc {
+	return func(l List) error { return rateError(l.count(), total, ratio) }
+}
+
+// FatalIfWarningRateAbove returns a FinalizeFunc failing if more than rate
+// (e.g. 0.05 for 5%) of the records warned, relative to List.Total as set by
+// Collector.SetTotal, so that data quality jobs fail on warning density
+// rather than on an absolute count. It never fails if Total is 0.
+func FatalIfWarningRateAbove(rate float64) FinalizeFunc {
+	return func(l List) error { return rateError(l.count(), l.Total, rate) }
+}
+
+// SetTotal sets the number of records (or other units of input) the
+// warnings collected by c relate to; see List.Total.
+func (c *Collector) SetTotal(n int) {
+	c.l.Total = n
+}
+
+// rateError returns a fatal error if more than ratio of total records
+// warned, and nil otherwise or if total is unknown.
+func rateError(n, total int, ratio float64) error {
+	if total <= 0 {
+		return nil
+	}
+	if r := float64(n) / float64(total); r > ratio {
+		return &Warning{Severity: Error, Msg: fmt.Sprintf(
+			"%d of %d records (%.1f%%) warned, more than the %.1f%% allowed",
+			n, total, r*100, ratio*100)}
+	}
+	return nil
+}
+
+// finalize calls c.Finalize, if set, and collects the error it returns, if
+// any, as the fatal error. It reports whether it did so, which ends
+// collection, closing Events and the Reporter as for any fatal error.
+func (c *Collector) finalize() bool {
+	if c.Finalize == nil || c.l.Fatal != nil {
+		return false
+	}
+	err := c.Finalize(c.List())
+	if err == nil {
+		return false
+	}
+	c.done = true
+	c.l.Fatal = err
+	c.phaseCollect(err, true)
+	c.emit(err, true)
+	c.report(err, true)
+	c.traceLog(err, true)
+	return true
+}
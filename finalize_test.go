@@ -0,0 +1,114 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestFinalize(t *testing.T) {
+	tests := []struct {
+		warns int
+		fatal bool
+		want  string
+	}{
+		{1, false, ""},
+		{2, false, "2 of 100 records (2.0%) warned, more than the 1.0% allowed"},
+		{2, true, "f"},
+	}
+	for _, tt := range tests {
+		c := w.NewCollector(isFatal)
+		c.Finalize = w.MaxWarningRatio(0.01, 100)
+		for i := 0; i < tt.warns; i++ {
+			c.Collect(warning("w"))
+		}
+		if tt.fatal {
+			c.Collect(fatal("f"))
+		}
+		fatal, warns := w.Split(c.Done())
+		var got string
+		if fatal != nil {
+			got = fatal.Error()
+		}
+		if got != tt.want || len(warns) != tt.warns {
+			t.Errorf("%d warnings: Done() = %q, %d warnings; want %q", tt.warns,
+				got, len(warns), tt.want)
+		}
+	}
+}
+
+func TestFinalizeHook(t *testing.T) {
+	var events []w.Event
+	c := &w.Collector{Hook: func(e w.Event) { events = append(events, e) }}
+	c.Finalize = func(l w.List) error {
+		if len(l.Warnings) > 0 {
+			return fatal("gate")
+		}
+		return nil
+	}
+	c.Collect(warning("1w"))
+	c.Done()
+	if len(events) != 2 || !events[1].Fatal {
+		t.Errorf("events = %v; want 1w, then fatal gate", events)
+	}
+}
+
+func TestFatalIfWarningRateAbove(t *testing.T) {
+	tests := []struct {
+		total int
+		want  string
+	}{
+		{0, ""},
+		{20, ""},
+		{19, "2 of 19 records (10.5%) warned, more than the 10.0% allowed"},
+	}
+	for _, tt := range tests {
+		c := w.NewCollector(isFatal)
+		c.Finalize = w.FatalIfWarningRateAbove(0.1)
+		c.SetTotal(tt.total)
+		c.Collect(warning("1w"))
+		c.Collect(warning("2w"))
+		fatal, _ := w.Split(c.Done())
+		var got string
+		if fatal != nil {
+			got = fatal.Error()
+		}
+		if got != tt.want {
+			t.Errorf("total %d: fatal = %q; want %q", tt.total, got, tt.want)
+		}
+		if l := c.List(); l.Total != tt.total {
+			t.Errorf("total %d: List().Total = %d", tt.total, l.Total)
+		}
+	}
+}
+
+func TestFinalizeEvents(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Finalize = func(l w.List) error { return fatal("gate") }
+	events := c.Events()
+	got := make(chan []w.Event)
+	go func() {
+		var es []w.Event
+		for e := range events {
+			es = append(es, e)
+		}
+		got <- es
+	}()
+	c.Collect(warning("1w"))
+	c.Done()
+	if es := <-got; len(es) != 2 || !es[1].Fatal || es[1].Err.Error() != "gate" {
+		t.Errorf("Events() = %v; want 1w, then fatal gate", es)
+	}
+}
+
+func TestFinalizeReporter(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Finalize = func(l w.List) error { return fatal("gate") }
+	r := &recordingReporter{}
+	c.Reporter = r
+	c.Collect(warning("1w"))
+	c.Done()
+	if len(r.warnings) != 1 || r.fatal == nil || r.fatal.Error() != "gate" {
+		t.Errorf("reported %v, %v; want [1w], gate", r.warnings, r.fatal)
+	}
+}
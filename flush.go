@@ -0,0 +1,114 @@
+package warnings
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// A Flusher is a sink holding pending deliveries, such as an Async. The
+// deliveries to the Reporter of a Collector are flushed by Flush as well.
+type Flusher interface {
+	// Flush waits until all pending deliveries have been made.
+	Flush()
+}
+
+// sinks is the registry of Flushers used by Flush.
+var sinks struct {
+	sync.Mutex
+	m map[*Flusher]Flusher
+}
+
+// RegisterSink registers s with the default registry flushed by Flush, so
+// that its pending deliveries aren't lost during shutdown. The returned
+// function unregisters it, e.g. before closing it.
+func RegisterSink(s Flusher) (unregister func()) {
+	key := &s
+	sinks.Lock()
+	defer sinks.Unlock()
+	if sinks.m == nil {
+		sinks.m = make(map[*Flusher]Flusher)
+	}
+	sinks.m[key] = s
+	return func() {
+		sinks.Lock()
+		defer sinks.Unlock()
+		delete(sinks.m, key)
+	}
+}
+
+// Flush flushes all sinks registered with RegisterSink concurrently, and
+// waits for them to finish or for ctx to be done, in which case it returns
+// ctx.Err().
+func Flush(ctx context.Context) error {
+	sinks.Lock()
+	var wg sync.WaitGroup
+	for _, s := range sinks.m {
+		wg.Add(1)
+		go func(s Flusher) {
+			defer wg.Done()
+			s.Flush()
+		}(s)
+	}
+	sinks.Unlock()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FlushOnSignal calls Flush with the given timeout when one of sigs (by
+// default, os.Interrupt and SIGTERM) is received, so that daemons don't lose
+// pending deliveries on shutdown. It then stops handling the signals and
+// raises the signal again, so that it takes its usual effect (such as
+// terminating the program); if that isn't possible, the program exits with
+// status 1. The signals are still delivered to other channels registered
+// using signal.Notify, which thus receive them twice. The returned function
+// stops it.
+func FlushOnSignal(timeout time.Duration, sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = shutdownSignals
+	}
+	ch := make(chan os.Signal, 1)
+	quit := make(chan struct{})
+	signal.Notify(ch, sigs...)
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(quit)
+		})
+	}
+	go func() {
+		select {
+		case sig := <-ch:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			Flush(ctx)
+			cancel()
+			stop()
+			raise(sig)
+		case <-quit:
+		}
+	}()
+	return stop
+}
+
+// raise sends sig to the current process, exiting if that fails.
+func raise(sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,56 @@
+package warnings_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+// flushFunc is a Flusher calling itself.
+type flushFunc func()
+
+func (f flushFunc) Flush() { f() }
+
+func TestFlush(t *testing.T) {
+	var got []string
+	a := w.NewAsync(16, func(e w.Event) { got = append(got, e.Err.Error()) })
+	defer a.Close()
+	unregister := w.RegisterSink(a)
+	a.Hook(w.Event{Err: warning("1w")})
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("after Flush, delivered %q; want [1w]", got)
+	}
+	unregister()
+
+	block := make(chan struct{})
+	defer close(block)
+	defer w.RegisterSink(flushFunc(func() { <-block }))()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := w.Flush(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Flush(blocked) = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestFlushReporter(t *testing.T) {
+	r := &recordingReporter{}
+	c := w.NewCollector(isFatal)
+	c.Reporter = r
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.warnings) != 2 {
+		t.Errorf("after Flush, reported %v; want [1w 2w]", r.warnings)
+	}
+	c.Done()
+	if err := w.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() after Done = %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build aix || darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package warnings_test
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestFlushOnSignal(t *testing.T) {
+	// keep the re-raised signal from terminating the test
+	other := make(chan os.Signal, 2)
+	signal.Notify(other, syscall.SIGUSR1)
+	defer signal.Stop(other)
+	flushed := make(chan struct{}, 1)
+	defer w.RegisterSink(flushFunc(func() { flushed <- struct{}{} }))()
+	stop := w.FlushOnSignal(time.Second, syscall.SIGUSR1)
+	defer stop()
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no Flush after signal")
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-other:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d signal(s), want the signal and its re-raise", i)
+		}
+	}
+}
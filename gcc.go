@@ -0,0 +1,58 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// GCC is a Renderer for the diagnostic format of GCC and Clang, so that
+// tools parsing compiler output (such as editors' quickfix lists) can consume
+// it unchanged. Errors are sorted as for Positional and rendered as
+//
+//	file:line:col: warning: message [CODE]
+//	file:line:col: note: hint
+//
+// with a "note:" line for the hint of a *Warning, if any. Fatal errors have
+// severity "error", and informational warnings "note".
+type GCC struct {
+	// Program, if set, replaces the position of errors without one, as in
+	// "cc1: warning: message".
+	Program string
+}
+
+// Render implements Renderer.
+func (g GCC) Render(w io.Writer, l List) error {
+	b := bytes.NewBuffer(nil)
+	for _, it := range positionalItems(l) {
+		loc := g.Program
+		if it.pos.Filename != "" || it.pos.IsValid() {
+			loc = it.pos.String()
+		}
+		if loc != "" {
+			loc += ": "
+		}
+		sev := SeverityOf(it.err)
+		switch {
+		case it.fatal:
+			fmt.Fprintf(b, "%serror: ", loc)
+		case sev == Info:
+			fmt.Fprintf(b, "%snote: ", loc)
+		default:
+			fmt.Fprintf(b, "%s%s: ", loc, sev)
+		}
+		fmt.Fprint(b, messageOf(it.err))
+		if n := countOf(it.err); n > 1 {
+			fmt.Fprintf(b, " (%d more times)", n-1)
+		}
+		if code := codeOf(it.err); code != "" {
+			fmt.Fprintf(b, " [%s]", code)
+		}
+		fmt.Fprintln(b)
+		if w, ok := AsWarning(it.err); ok && w.Hint != "" {
+			fmt.Fprintf(b, "%snote: %s\n", loc, w.Hint)
+		}
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
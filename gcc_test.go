@@ -0,0 +1,49 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var gccTests = []struct {
+	g    w.GCC
+	l    w.List
+	want string
+}{
+	{w.GCC{}, w.List{}, ""},
+	{
+		w.GCC{},
+		w.List{
+			Warnings: []error{
+				&w.Warning{Code: "C1", Msg: "1w", Pos: w.Position{"b", 2, 3}, Hint: "h"},
+				&w.Warning{Severity: w.Info, Msg: "2i", Pos: w.Position{"a", 1, 0}},
+				warning("3w"),
+			},
+			Fatal: &w.Warning{Code: "C4", Msg: "4f", Pos: w.Position{"b", 7, 1}},
+		},
+		"warning: 3w\n" +
+			"a:1: note: 2i\n" +
+			"b:2:3: warning: 1w [C1]\n" +
+			"b:2:3: note: h\n" +
+			"b:7:1: error: 4f [C4]\n",
+	},
+	{
+		w.GCC{Program: "app"},
+		w.List{Warnings: []error{&w.Warning{Msg: "1w", Count: 3, Hint: "h"}}},
+		"app: warning: 1w (2 more times)\napp: note: h\n",
+	},
+}
+
+func TestGCC(t *testing.T) {
+	for _, tt := range gccTests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.g.Render(b, tt.l); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%+v.Render() = %q; want %q", tt.g, b, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package warnings
+
+import "errors"
+
+// CollectReturn calls fn, collects the error it returns, and returns its
+// value together with whether collection can continue (i.e. whether Collect
+// returned nil). If it can't, the collected errors are returned by c.Done:
+//
+//	for _, s := range items {
+//		v, ok := warnings.CollectReturn(c, func() (int, error) {
+//			return parse(s)
+//		})
+//		if !ok {
+//			return c.Done()
+//		}
+//		...
+//	}
+func CollectReturn[T any](c *Collector, fn func() (T, error)) (T, bool) {
+	v, err := fn()
+	return v, c.Collect(err) == nil
+}
+
+// CollectValue is like CollectReturn, but returns def instead of the value
+// returned by fn if fn returns an error, implementing "warn and fall back to
+// a default" semantics:
+//
+//	port, ok := warnings.CollectValue(c, 8080, func() (int, error) {
+//		return strconv.Atoi(s)
+//	})
+//	if !ok {
+//		return c.Done()
+//	}
+func CollectValue[T any](c *Collector, def T, fn func() (T, error)) (T, bool) {
+	v, err := fn()
+	if err != nil {
+		v = def
+	}
+	return v, c.Collect(err) == nil
+}
+
+// Extract returns the warnings and the fatal error held by err (see Split)
+// that match type T according to errors.As, in the order they were
+// collected:
+//
+//	for _, d := range warnings.Extract[*DeprecationWarning](err) {
+//		...
+//	}
+func Extract[T error](err error) []T {
+	fatal, warns := Split(err)
+	if fatal != nil {
+		warns = append(warns[:len(warns):len(warns)], fatal)
+	}
+	var ts []T
+	for _, err := range warns {
+		var t T
+		if errors.As(err, &t) {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
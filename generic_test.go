@@ -0,0 +1,76 @@
+//go:build go1.18
+// +build go1.18
+
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestCollectReturn(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	tests := []struct {
+		v   int
+		err error
+		ok  bool
+	}{
+		{1, nil, true},
+		{2, warning("2w"), true},
+		{3, fatal("3f"), false},
+	}
+	for _, tt := range tests {
+		v, ok := w.CollectReturn(c, func() (int, error) { return tt.v, tt.err })
+		if v != tt.v || ok != tt.ok {
+			t.Errorf("CollectReturn() = %v, %v; want %v, %v", v, ok, tt.v, tt.ok)
+		}
+	}
+	if err := c.Done(); w.FatalOnly(err) != tests[2].err {
+		t.Errorf("Done() = %v; want fatal %v", err, tests[2].err)
+	}
+}
+
+func TestCollectValue(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	tests := []struct {
+		v    int
+		err  error
+		want int
+		ok   bool
+	}{
+		{1, nil, 1, true},
+		{2, warning("2w"), -1, true},
+		{3, fatal("3f"), -1, false},
+	}
+	for _, tt := range tests {
+		v, ok := w.CollectValue(c, -1, func() (int, error) { return tt.v, tt.err })
+		if v != tt.want || ok != tt.ok {
+			t.Errorf("CollectValue() = %v, %v; want %v, %v", v, ok, tt.want, tt.ok)
+		}
+	}
+	if err := c.Done(); w.FatalOnly(err) != tests[2].err {
+		t.Errorf("Done() = %v; want fatal %v", err, tests[2].err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.FatalWithWarnings = true
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	f := &w.Warning{Msg: "3f"}
+	c.Collect(f)
+	err := c.Done()
+	got, want := w.Extract[warn](err), []warn{"1w", "2w"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract[warn]() = %v; want %v", got, want)
+	}
+	if got := w.Extract[*w.Warning](err); len(got) != 1 || got[0] != f {
+		t.Errorf("Extract[*Warning]() = %v; want [%v]", got, f)
+	}
+	if got := w.Extract[warn](nil); got != nil {
+		t.Errorf("Extract[warn](nil) = %v; want nil", got)
+	}
+}
@@ -0,0 +1,65 @@
+package warnings
+
+import "sync"
+
+// global holds the process-global default Collector set by SetDefault.
+var global struct {
+	sync.Mutex
+	c *Collector
+}
+
+// SetDefault sets the process-global default Collector, to which Emit
+// collects errors outside of Capture; nil unsets it. It returns the previous
+// default, if any. The default Collector is locked while collecting into it,
+// so it may be used by several goroutines.
+func SetDefault(c *Collector) (prev *Collector) {
+	global.Lock()
+	defer global.Unlock()
+	prev, global.c = global.c, c
+	return prev
+}
+
+// Emit collects err into the Collector of the innermost Capture running on
+// the current goroutine, or otherwise into the default Collector set by
+// SetDefault, if any; otherwise err is dropped. It allows instrumenting code
+// that can't be passed a Collector yet. Errors emitted after collection has
+// ended are dropped. Emit returns the result of Collect, or nil if err was
+// dropped.
+func Emit(err error, attrs ...Attr) error {
+	if c, ok := Current(); ok {
+		return emitTo(c, err, attrs)
+	}
+	global.Lock()
+	defer global.Unlock()
+	if global.c == nil {
+		return nil
+	}
+	return emitTo(global.c, err, attrs)
+}
+
+func emitTo(c *Collector, err error, attrs []Attr) error {
+	if c.done {
+		return nil
+	}
+	return c.Collect(err, attrs...)
+}
+
+// Capture calls fn with a new Collector bound to the current goroutine (see
+// Bind), so that the errors passed to Emit by fn are collected into it rather
+// than the default Collector. It returns the List of errors collected, and
+// the fatal error, if any. Errors are classified using the IsFatal and
+// FatalFunc functions of the default Collector, if set, and otherwise by
+// severity, as for Collector.IsFatal.
+func Capture(fn func()) (List, error) {
+	c := NewCollector(nil)
+	global.Lock()
+	if global.c != nil {
+		c.IsFatal, c.FatalFunc = global.c.IsFatal, global.c.FatalFunc
+	}
+	global.Unlock()
+	unbind := Bind(c)
+	defer unbind()
+	fn()
+	c.Done()
+	return c.List(), c.Fatal()
+}
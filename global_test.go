@@ -0,0 +1,66 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+// legacy mimics code emitting warnings without being passed a Collector.
+func legacy(errs ...error) {
+	for _, err := range errs {
+		w.Emit(err)
+	}
+}
+
+func TestEmitDefault(t *testing.T) {
+	legacy(warning("dropped"))
+	c := w.NewCollector(isFatal)
+	prev := w.SetDefault(c)
+	defer w.SetDefault(prev)
+	legacy(warning("1w"), fatal("2f"), warning("3w"))
+	l := c.List()
+	if len(l.Warnings) != 1 || l.Fatal == nil {
+		t.Errorf("default List = %v; want 1 warning and a fatal error", l)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	def := w.NewCollector(isFatal)
+	prev := w.SetDefault(def)
+	defer w.SetDefault(prev)
+	var inner w.List
+	l, err := w.Capture(func() {
+		legacy(warning("1w"))
+		inner, _ = w.Capture(func() { legacy(warning("2w")) })
+		legacy(fatal("3f"), warning("4w"))
+	})
+	if len(l.Warnings) != 1 || err == nil || err.Error() != "3f" {
+		t.Errorf("Capture() = %v, %v; want 1 warning and fatal 3f", l, err)
+	}
+	if len(inner.Warnings) != 1 || inner.Warnings[0].Error() != "2w" {
+		t.Errorf("nested Capture() = %v; want [2w]", inner)
+	}
+	if n := len(def.Warnings()); n != 0 {
+		t.Errorf("default Collector got %d warnings; want 0", n)
+	}
+	legacy(warning("5w"))
+	if n := len(def.Warnings()); n != 1 {
+		t.Errorf("default Collector got %d warnings after Capture; want 1", n)
+	}
+}
+
+func TestCaptureDefaults(t *testing.T) {
+	prev := w.SetDefault(nil)
+	defer w.SetDefault(prev)
+	e := &w.Warning{Severity: w.Error, Msg: "1e"}
+	if _, err := w.Capture(func() { w.Emit(e) }); err != e {
+		t.Errorf("Capture() without default = %v; want fatal %v by severity", err, e)
+	}
+	w.SetDefault(&w.Collector{FatalFunc: func(_ w.CollectContext, err error) bool {
+		return err.Error() == "1w"
+	}})
+	if _, err := w.Capture(func() { w.Emit(warning("1w")) }); err == nil {
+		t.Errorf("Capture() = nil; want fatal 1w from the default FatalFunc")
+	}
+}
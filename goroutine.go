@@ -0,0 +1,67 @@
+package warnings
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// bound holds the Collectors bound to goroutines by Bind, per goroutine ID.
+var bound = struct {
+	sync.Mutex
+	m map[uint64]*Collector
+}{m: make(map[uint64]*Collector)}
+
+// Bind binds c to the current goroutine, so that functions called by it can
+// obtain c using Current without it being passed down, e.g. in code that
+// can't be changed to pass a context (see NewContext). The binding lasts
+// until the returned function is called, which restores the previous
+// binding, if any; goroutines started by the current one don't inherit it:
+//
+//	defer warnings.Bind(c)()
+//
+// Bind and Current are relatively expensive, as the goroutine is identified
+// from its stack trace.
+func Bind(c *Collector) (unbind func()) {
+	id := goroutineID()
+	bound.Lock()
+	prev, ok := bound.m[id]
+	bound.m[id] = c
+	bound.Unlock()
+	return func() {
+		bound.Lock()
+		if ok {
+			bound.m[id] = prev
+		} else {
+			delete(bound.m, id)
+		}
+		bound.Unlock()
+	}
+}
+
+// Current returns the Collector bound to the current goroutine by Bind, if
+// any.
+func Current() (*Collector, bool) {
+	id := goroutineID()
+	bound.Lock()
+	c, ok := bound.m[id]
+	bound.Unlock()
+	return c, ok
+}
+
+// goroutineID returns the ID of the current goroutine, which is found in the
+// first line of its stack trace ("goroutine 123 [running]:").
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		panic("warnings: can't identify goroutine")
+	}
+	return id
+}
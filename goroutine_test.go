@@ -0,0 +1,45 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func collectCurrent(err error) {
+	if c, ok := w.Current(); ok {
+		c.Collect(err)
+	}
+}
+
+func TestBind(t *testing.T) {
+	if _, ok := w.Current(); ok {
+		t.Fatalf("Current() ok = true before Bind")
+	}
+	c1, c2 := w.NewCollector(isFatal), w.NewCollector(isFatal)
+	unbind1 := w.Bind(c1)
+	collectCurrent(warning("1w"))
+	unbind2 := w.Bind(c2)
+	collectCurrent(warning("2w"))
+	done := make(chan bool)
+	go func() {
+		_, ok := w.Current()
+		done <- ok
+	}()
+	if <-done {
+		t.Errorf("Current() ok = true in another goroutine")
+	}
+	unbind2()
+	collectCurrent(warning("3w"))
+	unbind1()
+	collectCurrent(warning("4w"))
+	if _, ok := w.Current(); ok {
+		t.Errorf("Current() ok = true after unbinding")
+	}
+	if got := c1.Done().Error(); got != "warnings:\n1w\n3w\n" {
+		t.Errorf("c1.Done() = %q", got)
+	}
+	if got := c2.Done().Error(); got != "warning:\n2w\n" {
+		t.Errorf("c2.Done() = %q", got)
+	}
+}
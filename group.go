@@ -0,0 +1,69 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GroupBy splits l into Lists of errors sharing the same key. The fatal
+// errors, if any, end up in the Lists for their own keys.
+func (l List) GroupBy(key func(error) string) map[string]List {
+	groups := make(map[string]List)
+	for _, err := range l.Warnings {
+		k := key(err)
+		g := groups[k]
+		g.Warnings = append(g.Warnings, err)
+		groups[k] = g
+	}
+	for _, err := range l.FatalErrors() {
+		k := key(err)
+		g := groups[k]
+		g.AddFatal(err)
+		groups[k] = g
+	}
+	return groups
+}
+
+// ByFile is a key function for GroupBy; it returns the file name of the
+// position of a *Warning, or "" for other errors.
+func ByFile(err error) string {
+	if w, ok := AsWarning(err); ok {
+		return w.Pos.Filename
+	}
+	return ""
+}
+
+// WriteGroups writes groups (such as returned by GroupBy) to w, one section
+// per key in sorted order. Sections with an empty key are labeled "-".
+func WriteGroups(w io.Writer, groups map[string]List) error {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b := bytes.NewBuffer(nil)
+	for _, k := range keys {
+		label := k
+		if label == "" {
+			label = "-"
+		}
+		fmt.Fprintf(b, "%s:\n", label)
+		writeIndented(b, groups[k])
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeIndented writes the lines of l.Error() to b, indented by two spaces.
+func writeIndented(b *bytes.Buffer, l List) {
+	s := strings.TrimSuffix(l.Error(), "\n")
+	if s == "" {
+		return
+	}
+	for _, line := range strings.Split(s, "\n") {
+		fmt.Fprintf(b, "  %s\n", line)
+	}
+}
@@ -0,0 +1,51 @@
+package warnings_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var (
+	groupA1 = &w.Warning{Msg: "a1", Pos: w.Position{Filename: "a", Line: 1}}
+	groupA2 = &w.Warning{Msg: "a2", Pos: w.Position{Filename: "a", Line: 2}}
+	groupB1 = &w.Warning{Msg: "b1", Pos: w.Position{Filename: "b", Line: 1}}
+	groupL  = w.List{
+		Warnings: []error{groupA1, warning("1w"), groupA2},
+		Fatal:    groupB1,
+	}
+)
+
+func TestGroupBy(t *testing.T) {
+	want := map[string]w.List{
+		"a": {Warnings: []error{groupA1, groupA2}},
+		"b": {Fatal: groupB1},
+		"":  {Warnings: []error{warning("1w")}},
+	}
+	if got := groupL.GroupBy(w.ByFile); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy(ByFile) = %v; want %v", got, want)
+	}
+}
+
+func TestWriteGroups(t *testing.T) {
+	want := `-:
+  warning:
+  1w
+a:
+  warnings:
+  a:1: a1
+  a:2: a2
+b:
+  fatal:
+  b:1: b1
+`
+	b := bytes.NewBuffer(nil)
+	if err := w.WriteGroups(b, groupL.GroupBy(w.ByFile)); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("WriteGroups() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package warnings
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+// HandoffEnv is the environment variable through which a Handoff passes its
+// file to child processes.
+const HandoffEnv = "WARNINGS_HANDOFF"
+
+// A Handoff is a file through which child processes (such as helper binaries
+// run by a command line program) pass their warnings to the parent process.
+// The parent creates it using NewHandoff and adds Env to the environment of
+// the children; these call WriteHandoff before exiting; the parent then uses
+// Read to collect the result.
+type Handoff struct {
+	Path string
+	// FatalPolicy determines how the fatal errors of several child processes
+	// are merged by Read; by default, all of them are kept.
+	FatalPolicy FatalPolicy
+}
+
+// NewHandoff creates a Handoff backed by a new temporary file.
+func NewHandoff() (*Handoff, error) {
+	f, err := ioutil.TempFile("", "warnings-handoff-")
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &Handoff{Path: f.Name()}, nil
+}
+
+// Env returns the environment variable passing h to child processes, in the
+// form used by os/exec.Cmd.Env.
+func (h *Handoff) Env() string {
+	return HandoffEnv + "=" + h.Path
+}
+
+// Read returns the Lists written by the child processes merged into one. The
+// warnings are concatenated, the counts are added up, and the fatal errors
+// are merged in the order written according to h.FatalPolicy (see
+// List.Merge).
+func (h *Handoff) Read() (List, error) {
+	var l List
+	f, err := os.Open(h.Path)
+	if err != nil {
+		return l, err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 64<<20)
+	for s.Scan() {
+		var cl List
+		if err := json.Unmarshal(s.Bytes(), &cl); err != nil {
+			return l, err
+		}
+		l.Merge(cl, h.FatalPolicy)
+	}
+	return l, s.Err()
+}
+
+// Close removes the file of h.
+func (h *Handoff) Close() error {
+	return os.Remove(h.Path)
+}
+
+// WriteHandoff appends l to the Handoff file named by the HandoffEnv
+// environment variable, if set. It is called by child processes.
+func WriteHandoff(l List) error {
+	path := os.Getenv(HandoffEnv)
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
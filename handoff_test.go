@@ -0,0 +1,43 @@
+package warnings_test
+
+import (
+	"os"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestHandoff(t *testing.T) {
+	h, err := w.NewHandoff()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	if err := w.WriteHandoff(w.List{Warnings: []error{warning("0w")}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := w.HandoffEnv + "=" + h.Path; h.Env() != want {
+		t.Errorf("Env() = %q; want %q", h.Env(), want)
+	}
+	os.Setenv(w.HandoffEnv, h.Path)
+	defer os.Unsetenv(w.HandoffEnv)
+	children := []w.List{
+		{Warnings: []error{warning("1w")}, Omitted: 1},
+		{Warnings: []error{warning("2w")}, Fatal: fatal("3f")},
+		{Fatal: fatal("4f"), Drained: 2},
+	}
+	for _, l := range children {
+		if err := w.WriteHandoff(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l, err := h.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "fatal:\n3f\n4f\nwarnings:\n(2 earlier warning(s) already written)\n" +
+		"1w\n2w\n(1 more warning(s) omitted)\n"
+	if l.Error() != want {
+		t.Errorf("Read() = %q; want %q", l.Error(), want)
+	}
+}
@@ -0,0 +1,63 @@
+package warnings
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HealthStatus is the overall status of a health report. The values follow
+// the draft "Health Check Response Format for HTTP APIs".
+type HealthStatus string
+
+// Health statuses.
+const (
+	Healthy  HealthStatus = "pass" // no warnings and no fatal error
+	Degraded HealthStatus = "warn" // warnings, but no fatal error
+	Failing  HealthStatus = "fail" // fatal error
+)
+
+// Health is a health report on a List.
+type Health struct {
+	Status HealthStatus `json:"status"`
+	Output string       `json:"output,omitempty"` // the fatal error, if any
+	Notes  []string     `json:"notes,omitempty"`  // the warnings, if any
+}
+
+// Health returns a health report on l. Only the warnings in l.Warnings count;
+// expired, drained and omitted ones don't keep l from being Healthy again.
+func (l List) Health() Health {
+	var h Health
+	switch {
+	case l.Fatal != nil:
+		h.Status, h.Output = Failing, l.Fatal.Error()
+	case len(l.Warnings) > 0:
+		h.Status = Degraded
+	default:
+		h.Status = Healthy
+	}
+	for _, err := range l.Warnings {
+		h.Notes = append(h.Notes, err.Error())
+	}
+	return h
+}
+
+// HealthHandler returns an http.Handler serving the health report on the List
+// returned by state (e.g. Collector.List) as JSON. The status code is 503
+// (Service Unavailable) if the status is Failing, and 200 (OK) otherwise.
+// Since state is called concurrently with the HTTP server's goroutines, it
+// must be synchronized with collection.
+func HealthHandler(state func() List) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := state().Health()
+		data, err := json.Marshal(h)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/health+json")
+		if h.Status == Failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write(data)
+	})
+}
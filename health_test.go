@@ -0,0 +1,53 @@
+package warnings_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var healthTests = []struct {
+	l    w.List
+	want w.Health
+}{
+	{w.List{}, w.Health{Status: w.Healthy}},
+	{w.List{Warnings: []error{warning("1w")}},
+		w.Health{Status: w.Degraded, Notes: []string{"1w"}}},
+	{w.List{Expired: 1, Drained: 1, Omitted: 1}, w.Health{Status: w.Healthy}},
+	{w.List{Warnings: []error{warning("1w")}, Fatal: fatal("2f")},
+		w.Health{Status: w.Failing, Output: "2f", Notes: []string{"1w"}}},
+}
+
+func TestHealth(t *testing.T) {
+	for _, tt := range healthTests {
+		if got := tt.l.Health(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%#v.Health() = %#v; want %#v", tt.l, got, tt.want)
+		}
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	h := w.HealthHandler(c.List)
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/health", nil))
+		return rec
+	}
+	c.Collect(warning("1w"))
+	rec := get()
+	if want := `{"status":"warn","notes":["1w"]}`; rec.Code != http.StatusOK ||
+		rec.Body.String() != want {
+		t.Errorf("GET = %d %s; want 200 %s", rec.Code, rec.Body, want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/health+json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	c.Collect(fatal("2f"))
+	if rec := get(); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET after fatal = %d; want 503", rec.Code)
+	}
+}
@@ -0,0 +1,132 @@
+// Package html renders warnings as a standalone HTML report, which can be
+// filtered by severity, code and file in the browser. It is intended for
+// large collections whose plain text rendering is impractical to read.
+package html // import "gopkg.in/warnings.v0/html"
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/warnings.v0"
+)
+
+type row struct {
+	Severity string
+	Code     string
+	File     string
+	Line     int
+	Message  string
+}
+
+type report struct {
+	Title      string
+	Rows       []row
+	Severities []string
+	Codes      []string
+	Files      []string
+}
+
+// Write writes a standalone HTML report with the given title for the errors
+// in l to w. The fatal errors, if any, are listed first with severity
+// "fatal".
+func Write(w io.Writer, title string, l warnings.List) error {
+	r := report{Title: title}
+	for _, err := range l.FatalErrors() {
+		r.Rows = append(r.Rows, newRow(err, "fatal"))
+	}
+	for _, err := range l.Warnings {
+		r.Rows = append(r.Rows, newRow(err, warnings.SeverityOf(err).String()))
+	}
+	sevs, codes, files := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, row := range r.Rows {
+		sevs[row.Severity] = true
+		codes[row.Code] = true
+		files[row.File] = true
+	}
+	r.Severities, r.Codes, r.Files = keys(sevs), keys(codes), keys(files)
+	return reportTemplate.Execute(w, r)
+}
+
+func newRow(err error, severity string) row {
+	r := row{Severity: severity, Message: err.Error()}
+	var w *warnings.Warning
+	if errors.As(err, &w) {
+		r.Code, r.File, r.Line = w.Code, w.Pos.Filename, w.Pos.Line
+		// keep any context err adds to w, but not the position, which has
+		// columns of its own
+		nw := *w
+		nw.Pos = warnings.Position{}
+		r.Message = strings.Replace(r.Message, w.Error(), nw.Error(), 1)
+	}
+	return r
+}
+
+func keys(m map[string]bool) []string {
+	var ks []string
+	for k := range m {
+		if k != "" {
+			ks = append(ks, k)
+		}
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 1em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.2em 0.5em; border-bottom: 1px solid #ddd; }
+tr.fatal { background: #fdd; }
+tr.error { background: #fee; }
+tr.warning { background: #ffe; }
+#filters { margin-bottom: 1em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div id="filters">
+<select id="severity"><option value="">all severities</option>{{range .Severities}}<option>{{.}}</option>{{end}}</select>
+<select id="code"><option value="">all codes</option>{{range .Codes}}<option>{{.}}</option>{{end}}</select>
+<select id="file"><option value="">all files</option>{{range .Files}}<option>{{.}}</option>{{end}}</select>
+<input id="text" type="search" placeholder="filter messages">
+<span id="count">{{len .Rows}}</span> shown
+</div>
+<table>
+<thead><tr><th>Severity</th><th>Code</th><th>File</th><th>Line</th><th>Message</th></tr></thead>
+<tbody>
+{{range .Rows}}<tr class="{{.Severity}}" data-severity="{{.Severity}}" data-code="{{.Code}}" data-file="{{.File}}"><td>{{.Severity}}</td><td>{{.Code}}</td><td>{{.File}}</td><td>{{if .Line}}{{.Line}}{{end}}</td><td>{{.Message}}</td></tr>
+{{end}}</tbody>
+</table>
+<script>
+(function() {
+  var ids = ["severity", "code", "file"];
+  var rows = document.querySelectorAll("tbody tr");
+  function filter() {
+    var text = document.getElementById("text").value.toLowerCase();
+    var shown = 0;
+    rows.forEach(function(row) {
+      var ok = ids.every(function(id) {
+        var v = document.getElementById(id).value;
+        return v === "" || row.dataset[id] === v;
+      }) && row.textContent.toLowerCase().indexOf(text) >= 0;
+      row.style.display = ok ? "" : "none";
+      if (ok) shown++;
+    });
+    document.getElementById("count").textContent = shown;
+  }
+  ids.concat(["text"]).forEach(function(id) {
+    document.getElementById(id).addEventListener("input", filter);
+  });
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,42 @@
+package html_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/html"
+)
+
+func TestWrite(t *testing.T) {
+	l := warnings.List{
+		Warnings: []error{
+			&warnings.Warning{Code: "C1", Msg: "<bad>",
+				Pos: warnings.Position{Filename: "a.cfg", Line: 2}},
+			errors.New("plain"),
+			fmt.Errorf("loading x: %w", &warnings.Warning{Code: "C2", Msg: "m",
+				Pos: warnings.Position{Filename: "b.cfg", Line: 3}}),
+		},
+		Fatal: errors.New("fatal"),
+	}
+	b := bytes.NewBuffer(nil)
+	if err := html.Write(b, "Report & more", l); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"<title>Report &amp; more</title>",
+		`<tr class="fatal" data-severity="fatal" data-code="" data-file=""><td>fatal</td>`,
+		`data-code="C1" data-file="a.cfg"><td>warning</td><td>C1</td><td>a.cfg</td><td>2</td><td>&lt;bad&gt;</td>`,
+		"<option>C1</option>",
+		"<option>a.cfg</option>",
+		`data-code="C2" data-file="b.cfg"><td>warning</td><td>C2</td><td>b.cfg</td><td>3</td><td>loading x: m</td>`,
+		`<span id="count">4</span>`,
+	} {
+		if !strings.Contains(b.String(), s) {
+			t.Errorf("Write() output doesn't contain %q:\n%s", s, b)
+		}
+	}
+}
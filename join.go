@@ -0,0 +1,51 @@
+package warnings
+
+import (
+	"errors"
+	"strings"
+)
+
+// A joinedError joins several errors, like the errors returned by errors.Join
+// (which doesn't exist before Go 1.20). It is returned by List.Joined, and for
+// fatal errors merged with JoinFatals.
+//
+// Its Is and As methods, to which those of the other multi-errors of the
+// package delegate, look into the joined errors for Go versions before 1.20,
+// whose errors.Is and errors.As don't support Unwrap() []error.
+type joinedError struct {
+	errs []error
+	msg  string // the message, if not that of errs separated by newlines
+}
+
+func (e *joinedError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	s := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e *joinedError) Unwrap() []error { return e.errs }
+
+// Is reports whether any of the joined errors matches target.
+func (e *joinedError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the joined errors that matches target.
+func (e *joinedError) As(target interface{}) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
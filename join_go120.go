@@ -0,0 +1,12 @@
+//go:build go1.20
+// +build go1.20
+
+package warnings
+
+import (
+	"errors"
+	"reflect"
+)
+
+// joinType is the type of the errors returned by errors.Join.
+var joinType = reflect.TypeOf(errors.Join(errors.New("")))
@@ -0,0 +1,10 @@
+//go:build !go1.20
+// +build !go1.20
+
+package warnings
+
+import "reflect"
+
+// joinType is the type of the errors returned by errors.Join, which doesn't
+// exist before Go 1.20.
+var joinType reflect.Type
@@ -0,0 +1,199 @@
+package warnings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// jsonWarning is the JSON representation of a single error.
+type jsonWarning struct {
+	Code     string                 `json:"code,omitempty"`
+	Severity Severity               `json:"severity,omitempty"` // omitted for Warn
+	Message  string                 `json:"message"`
+	File     string                 `json:"file,omitempty"`
+	Line     int                    `json:"line,omitempty"`
+	Column   int                    `json:"column,omitempty"`
+	Hint     string                 `json:"hint,omitempty"`
+	Cause    string                 `json:"cause,omitempty"`
+	Count    int                    `json:"count,omitempty"`
+	Priority int                    `json:"priority,omitempty"`
+	Attrs    map[string]interface{} `json:"attrs,omitempty"`
+	Section  *jsonList              `json:"section,omitempty"` // for a *Section, labeled by Message
+}
+
+// WireVersion is the version of the JSON format of Lists, written as the
+// "version" field by List.MarshalJSON. It is incremented on incompatible
+// changes only; Lists written by older versions of this package (including
+// those without a version) are still decoded, as are Lists with fields added
+// compatibly (which are ignored), but Lists of a newer version are rejected.
+const WireVersion = 1
+
+// jsonEnvelope is the JSON representation of a top-level List.
+type jsonEnvelope struct {
+	Version int `json:"version"`
+	jsonList
+}
+
+// jsonList is the JSON representation of a List.
+type jsonList struct {
+	Warnings []jsonWarning `json:"warnings,omitempty"`
+	Fatal    *jsonWarning  `json:"fatal,omitempty"`
+	Fatals   []jsonWarning `json:"fatals,omitempty"`
+	Drained  int           `json:"drained,omitempty"`
+	Omitted  int           `json:"omitted,omitempty"`
+	Expired  int           `json:"expired,omitempty"`
+	Total    int           `json:"total,omitempty"`
+}
+
+func toJSONWarning(err error) jsonWarning {
+	if s, ok := err.(*Section); ok {
+		jl := toJSONList(s.List)
+		return jsonWarning{Message: s.Label, Section: &jl}
+	}
+	w, ok := AsWarning(err)
+	if !ok {
+		return jsonWarning{Message: err.Error()}
+	}
+	jw := jsonWarning{Code: w.Code, Severity: w.Severity, Message: w.Msg,
+		File: w.Pos.Filename, Line: w.Pos.Line, Column: w.Pos.Column,
+		Hint: w.Hint, Count: w.Count, Priority: w.Priority}
+	if w.Err != nil {
+		jw.Cause = w.Err.Error()
+	}
+	if len(w.Attrs) > 0 {
+		jw.Attrs = make(map[string]interface{}, len(w.Attrs))
+		for _, a := range w.Attrs {
+			jw.Attrs[a.Key] = a.Value
+		}
+	}
+	return jw
+}
+
+func (jw jsonWarning) warning() *Warning {
+	w := &Warning{Code: jw.Code, Severity: jw.Severity, Msg: jw.Message,
+		Pos: Position{jw.File, jw.Line, jw.Column}, Hint: jw.Hint,
+		Count: jw.Count, Priority: jw.Priority}
+	if jw.Cause != "" {
+		w.Err = errors.New(jw.Cause)
+	}
+	for k, v := range jw.Attrs {
+		w.Attrs = append(w.Attrs, Attr{k, v})
+	}
+	sort.Slice(w.Attrs, func(i, j int) bool {
+		return w.Attrs[i].Key < w.Attrs[j].Key
+	})
+	return w
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w *Warning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONWarning(w))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The cause, if any, is restored
+// as an error with the same message; attributes are restored in key order.
+func (w *Warning) UnmarshalJSON(data []byte) error {
+	var jw jsonWarning
+	if err := json.Unmarshal(data, &jw); err != nil {
+		return err
+	}
+	*w = *jw.warning()
+	return nil
+}
+
+// error returns the error represented by jw: a *Section or a *Warning.
+func (jw jsonWarning) error() error {
+	if jw.Section != nil {
+		return &Section{Label: jw.Message, List: jw.Section.list()}
+	}
+	return jw.warning()
+}
+
+// MarshalJSON implements json.Marshaler. Errors other than *Warning and
+// *Section are represented by their message.
+func (l List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonEnvelope{WireVersion, toJSONList(l)})
+}
+
+func toJSONList(l List) jsonList {
+	var jl jsonList
+	for _, err := range l.Warnings {
+		jl.Warnings = append(jl.Warnings, toJSONWarning(err))
+	}
+	if l.Fatal != nil {
+		jw := toJSONWarning(l.Fatal)
+		jl.Fatal = &jw
+	}
+	for _, err := range l.Fatals {
+		jl.Fatals = append(jl.Fatals, toJSONWarning(err))
+	}
+	jl.Drained, jl.Omitted, jl.Expired = l.Drained, l.Omitted, l.Expired
+	jl.Total = l.Total
+	return jl
+}
+
+// UnmarshalJSON implements json.Unmarshaler. All errors are restored as
+// *Warning values, except for sections, which are restored as *Section
+// values.
+func (l *List) UnmarshalJSON(data []byte) error {
+	var env jsonEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+	if env.Version > WireVersion {
+		return fmt.Errorf("warnings: unsupported wire version %d (want at most %d)",
+			env.Version, WireVersion)
+	}
+	*l = env.list()
+	return nil
+}
+
+func (jl jsonList) list() List {
+	l := List{Drained: jl.Drained, Omitted: jl.Omitted, Expired: jl.Expired,
+		Total: jl.Total}
+	for _, jw := range jl.Warnings {
+		l.Warnings = append(l.Warnings, jw.error())
+	}
+	if jl.Fatal != nil {
+		l.Fatal = jl.Fatal.error()
+	}
+	for i, jw := range jl.Fatals {
+		if i == 0 && l.Fatal != nil {
+			l.Fatals = append(l.Fatals, l.Fatal)
+			continue
+		}
+		l.AddFatal(jw.error())
+	}
+	return l
+}
+
+// Save writes the errors collected so far to w as JSON, so that collection
+// can later be resumed using Load (e.g. by a restarted batch job). Phases are
+// not saved.
+func (c *Collector) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(c.l)
+}
+
+// Load replaces the errors collected so far by those read from r, as written
+// by Save. The loaded errors are restored as for List.UnmarshalJSON; for TTL,
+// they count as collected when loaded. If the loaded state contains a fatal
+// error, the Collector is done.
+//
+// Only the List is restored: the state of OncePerCode, DedupWindow and
+// Escalate starts afresh, so that a warning repeating one loaded is kept (or
+// counted towards escalation) again, and phases are lost.
+func (c *Collector) Load(r io.Reader) error {
+	var l List
+	if err := json.NewDecoder(r).Decode(&l); err != nil {
+		return err
+	}
+	c.l = l
+	c.done = l.Fatal != nil
+	c.once = nil
+	c.times = nil
+	c.stamp()
+	return nil
+}
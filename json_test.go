@@ -0,0 +1,155 @@
+package warnings_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var jsonList = w.List{
+	Warnings: []error{
+		warning("1w"),
+		&w.Warning{Code: "C1", Severity: w.Error, Msg: "msg",
+			Pos: w.Position{"f", 1, 2}, Err: errors.New("cause")},
+	},
+	Fatal:   fatal("3f"),
+	Drained: 4,
+}
+
+func TestListJSON(t *testing.T) {
+	data, err := json.Marshal(jsonList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":1,"warnings":[{"message":"1w"},{"code":"C1","severity":"error","message":"msg",` +
+		`"file":"f","line":1,"column":2,"cause":"cause"}],` +
+		`"fatal":{"message":"3f"},"drained":4}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s; want %s", data, want)
+	}
+	var l w.List
+	if err := json.Unmarshal(data, &l); err != nil {
+		t.Fatal(err)
+	}
+	if l.Error() != jsonList.Error() {
+		t.Errorf("round trip Error() = %q; want %q", l.Error(),
+			jsonList.Error())
+	}
+	if wn, ok := l.Warnings[1].(*w.Warning); !ok || wn.Code != "C1" ||
+		wn.Severity != w.Error || wn.Pos != (w.Position{"f", 1, 2}) {
+		t.Errorf("round trip Warnings[1] = %#v", l.Warnings[1])
+	}
+}
+
+func TestCollectorSaveLoad(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"))
+	b := bytes.NewBuffer(nil)
+	if err := c.Save(b); err != nil {
+		t.Fatal(err)
+	}
+	c2 := w.NewCollector(isFatal)
+	if err := c2.Load(b); err != nil {
+		t.Fatal(err)
+	}
+	c2.Collect(warning("2w"))
+	err := c2.Done()
+	var msgs []string
+	for _, err := range w.WarningsOnly(err) {
+		msgs = append(msgs, err.Error())
+	}
+	if want := []string{"1w", "2w"}; !reflect.DeepEqual(msgs, want) {
+		t.Errorf("Done() warnings = %v; want %v", msgs, want)
+	}
+}
+
+func TestCollectorLoadFatalSection(t *testing.T) {
+	l := w.List{Fatal: &w.Section{Label: "doc",
+		List: w.List{Warnings: []error{warning("1w")}}}}
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := w.NewCollector(isFatal)
+	if err := c.Load(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	fatal := c.Fatal()
+	if s, ok := fatal.(*w.Section); !ok || s.Label != "doc" || len(s.List.Warnings) != 1 {
+		t.Errorf("loaded fatal = %#v; want *Section doc", fatal)
+	}
+}
+
+func TestListJSONFatals(t *testing.T) {
+	var l w.List
+	l.AddFatal(fatal("1f"))
+	l.AddFatal(fatal("2f"))
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":1,"fatal":{"message":"1f"},"fatals":[{"message":"1f"},{"message":"2f"}]}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s; want %s", data, want)
+	}
+	var l2 w.List
+	if err := json.Unmarshal(data, &l2); err != nil {
+		t.Fatal(err)
+	}
+	if len(l2.Fatals) != 2 || l2.Fatals[0] != l2.Fatal || l2.Error() != l.Error() {
+		t.Errorf("round trip = %#v; want %#v", l2, l)
+	}
+}
+
+func TestWarningJSONPriority(t *testing.T) {
+	data, err := json.Marshal(&w.Warning{Msg: "msg", Priority: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"message":"msg","priority":3}`; string(data) != want {
+		t.Errorf("Marshal() = %s; want %s", data, want)
+	}
+	var got w.Warning
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if w.PriorityOf(&got) != 3 {
+		t.Errorf("round trip Priority = %d; want 3", got.Priority)
+	}
+}
+
+func TestListJSONVersions(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		// Written before versioning.
+		{`{"warnings":[{"message":"1w"}],"fatal":{"message":"2f"}}`,
+			"fatal:\n2f\nwarning:\n1w\n"},
+		{`{"version":1,"warnings":[{"message":"1w"}],"omitted":2}`,
+			"warnings:\n1w\n(2 more warning(s) omitted)\n"},
+		// With compatibly added fields unknown to this version.
+		{`{"version":1,"warnings":[{"message":"1w","new":true}],"new":{}}`,
+			"warning:\n1w\n"},
+	}
+	for _, tt := range tests {
+		var l w.List
+		if err := json.Unmarshal([]byte(tt.data), &l); err != nil {
+			t.Errorf("Unmarshal(%s) = %v", tt.data, err)
+			continue
+		}
+		if l.Error() != tt.want {
+			t.Errorf("Unmarshal(%s) = %q; want %q", tt.data, l.Error(), tt.want)
+		}
+	}
+	// Written by a newer, incompatible version.
+	var l w.List
+	data := `{"version":2,"warnings":[{"message":"1w"}]}`
+	if err := json.Unmarshal([]byte(data), &l); err == nil {
+		t.Errorf("Unmarshal(%s) = nil; want an error", data)
+	}
+}
@@ -0,0 +1,78 @@
+package warnings
+
+// A KeyedCollector collects errors separately per key (such as a record ID or
+// a partition), each key behaving like its own Collector.
+type KeyedCollector struct {
+	// IsFatal distinguishes between warnings and fatal errors.
+	IsFatal func(error) bool
+	// FatalFunc has the same meaning as for Collector; CollectContext.Key
+	// is set to the key, and the other fields describe the collection for
+	// that key.
+	FatalFunc func(ctx CollectContext, err error) bool
+	// FatalWithWarnings has the same meaning as for Collector; it applies to
+	// the errors returned by Collect and to the aggregate returned by Done.
+	FatalWithWarnings bool
+	// MaxPerKey, if > 0, limits the number of warnings kept per key, as
+	// Collector.MaxWarnings does; further warnings for a key are only counted
+	// in the Omitted fields of its List and of the aggregate.
+	MaxPerKey int
+
+	cs   map[string]*Collector
+	l    List
+	done bool
+}
+
+// NewKeyedCollector returns a new KeyedCollector; it uses isFatal to
+// distinguish between warnings and fatal errors.
+func NewKeyedCollector(isFatal func(error) bool) *KeyedCollector {
+	return &KeyedCollector{IsFatal: isFatal}
+}
+
+// Collect collects a single error (warning or fatal) for key, with optional
+// attributes as for Collector.Collect. It returns nil if collection can
+// continue for key (only warnings so far), or otherwise the errors collected
+// for key. Collect mustn't be called for a key after its first fatal error, or
+// after Done has been called.
+func (kc *KeyedCollector) Collect(key string, err error, attrs ...Attr) error {
+	if kc.done {
+		panic("warnings.KeyedCollector already done")
+	}
+	if kc.cs == nil {
+		kc.cs = make(map[string]*Collector)
+	}
+	c, ok := kc.cs[key]
+	if !ok {
+		c = &Collector{IsFatal: kc.IsFatal, FatalFunc: kc.FatalFunc,
+			FatalWithWarnings: kc.FatalWithWarnings, MaxWarnings: kc.MaxPerKey,
+			key: key}
+		kc.cs[key] = c
+	}
+	n, omitted := len(c.l.Warnings), c.l.Omitted
+	cerr := c.Collect(err, attrs...)
+	kc.l.Omitted += c.l.Omitted - omitted
+	switch {
+	case c.done && c.l.Fatal != nil:
+		if kc.l.Fatal == nil {
+			kc.l.Fatal = c.l.Fatal
+		}
+	case len(c.l.Warnings) > n:
+		kc.l.Warnings = append(kc.l.Warnings, c.l.Warnings[n])
+	}
+	return cerr
+}
+
+// Done ends collection. It returns the errors collected per key, and the
+// aggregate of all of them as a single error: all warnings in collection
+// order, and the first fatal error, if any.
+func (kc *KeyedCollector) Done() (map[string]List, error) {
+	kc.done = true
+	m := make(map[string]List, len(kc.cs))
+	for k, c := range kc.cs {
+		c.Done()
+		if c.l.Fatal != nil || len(c.l.Warnings) > 0 {
+			m[k] = c.l
+		}
+	}
+	agg := Collector{FatalWithWarnings: kc.FatalWithWarnings, l: kc.l}
+	return m, agg.erorr()
+}
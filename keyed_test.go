@@ -0,0 +1,67 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestKeyedCollector(t *testing.T) {
+	kc := w.NewKeyedCollector(isFatal)
+	kc.FatalWithWarnings = true
+	steps := []struct {
+		key   string
+		err   error
+		fatal bool
+	}{
+		{"r1", warning("1w"), false},
+		{"r2", warning("2w"), false},
+		{"r1", nil, false},
+		{"r2", fatal("3f"), true},
+		{"r1", warning("4w"), false},
+		{"r3", fatal("5f"), true},
+	}
+	for _, s := range steps {
+		err := kc.Collect(s.key, s.err)
+		if s.fatal != (err != nil) {
+			t.Fatalf("Collect(%q, %v) = %v", s.key, s.err, err)
+		}
+	}
+	lists, err := kc.Done()
+	wantLists := map[string]w.List{
+		"r1": {Warnings: []error{warning("1w"), warning("4w")}},
+		"r2": {Warnings: []error{warning("2w")}, Fatal: steps[3].err},
+		"r3": {Fatal: steps[5].err},
+	}
+	if !reflect.DeepEqual(lists, wantLists) {
+		t.Errorf("Done() = %v; want %v", lists, wantLists)
+	}
+	if w.FatalOnly(err) != steps[3].err {
+		t.Errorf("Done() aggregate fatal = %v; want %v", w.FatalOnly(err),
+			steps[3].err)
+	}
+	wantWarns := []error{warning("1w"), warning("2w"), warning("4w")}
+	if warns := w.WarningsOnly(err); !reflect.DeepEqual(warns, wantWarns) {
+		t.Errorf("Done() aggregate warnings = %v; want %v", warns, wantWarns)
+	}
+}
+
+func TestKeyedCollectorMaxPerKey(t *testing.T) {
+	kc := w.NewKeyedCollector(isFatal)
+	kc.MaxPerKey = 2
+	for i := 0; i < 5; i++ {
+		kc.Collect("bad", warning("bw"))
+	}
+	kc.Collect("good", warning("gw"))
+	m, err := kc.Done()
+	if l := m["bad"]; len(l.Warnings) != 2 || l.Omitted != 3 {
+		t.Errorf("bad = %v; want 2 warnings, 3 omitted", l)
+	}
+	if l := m["good"]; len(l.Warnings) != 1 {
+		t.Errorf("good = %v; want 1 warning", l)
+	}
+	if l, _ := err.(w.List); len(l.Warnings) != 3 || l.Omitted != 3 {
+		t.Errorf("Done() = %v; want 3 warnings, 3 omitted", err)
+	}
+}
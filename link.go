@@ -0,0 +1,77 @@
+package warnings
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// A LinkFunc returns the URL to link a position to in terminal output (see
+// Text.Link), or "" for none.
+type LinkFunc func(pos Position) string
+
+// FileLink is a LinkFunc linking positions to the file:// URL of their file.
+func FileLink(pos Position) string {
+	if pos.Filename == "" {
+		return ""
+	}
+	return (&url.URL{Scheme: "file", Path: absPath(pos.Filename)}).String()
+}
+
+// EditorLink returns a LinkFunc linking positions to URLs opening them in an
+// editor, built from pattern by replacing "{path}" with the absolute path of
+// the file (escaped as a URL path, so that spaces, "#" and "?" are
+// preserved), and "{line}" and "{column}" with the line and column (at least
+// 1), e.g. "vscode://file{path}:{line}:{column}" or
+// "idea://open?file={path}&line={line}".
+func EditorLink(pattern string) LinkFunc {
+	return func(pos Position) string {
+		if pos.Filename == "" {
+			return ""
+		}
+		line, col := pos.Line, pos.Column
+		if line < 1 {
+			line = 1
+		}
+		if col < 1 {
+			col = 1
+		}
+		path := (&url.URL{Path: absPath(pos.Filename)}).EscapedPath()
+		return strings.NewReplacer("{path}", path,
+			"{line}", strconv.Itoa(line),
+			"{column}", strconv.Itoa(col)).Replace(pattern)
+	}
+}
+
+// linkPosition returns the text of pos, as an OSC 8 hyperlink if link
+// returns a URL for it.
+func linkPosition(link LinkFunc, pos Position) string {
+	s := pos.String()
+	if link == nil {
+		return s
+	}
+	if u := link(pos); u != "" {
+		return hyperlink(u, s)
+	}
+	return s
+}
+
+// hyperlink returns text as an OSC 8 terminal hyperlink to u.
+func hyperlink(u, text string) string {
+	return "\x1b]8;;" + u + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// absPath returns the file name made absolute relative to the current
+// directory, with forward slashes and a leading slash (as in /C:/dir on
+// Windows), for use in URLs.
+func absPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs
+	}
+	name = filepath.ToSlash(name)
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	return name
+}
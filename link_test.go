@@ -0,0 +1,80 @@
+package warnings_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestEditorLink(t *testing.T) {
+	abs, err := filepath.Abs("dir/f.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs = filepath.ToSlash(abs)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs
+	}
+	tests := []struct {
+		link w.LinkFunc
+		pos  w.Position
+		want string
+	}{
+		{w.FileLink, w.Position{Filename: "dir/f.go", Line: 3}, "file://" + abs},
+		{w.FileLink, w.Position{Line: 3}, ""},
+		{w.EditorLink("vscode://file{path}:{line}:{column}"),
+			w.Position{Filename: "dir/f.go", Line: 3}, "vscode://file" + abs + ":3:1"},
+		{w.EditorLink("idea://open?file={path}&line={line}"),
+			w.Position{Filename: "dir/f.go", Line: 3, Column: 7},
+			"idea://open?file=" + abs + "&line=3"},
+	}
+	if filepath.IsAbs("/a dir") { // not on Windows
+		tests = append(tests, struct {
+			link w.LinkFunc
+			pos  w.Position
+			want string
+		}{w.EditorLink("vscode://file{path}:{line}"),
+			w.Position{Filename: "/a dir/#1?.go", Line: 3},
+			"vscode://file/a%20dir/%231%3F.go:3"})
+	}
+	for _, tt := range tests {
+		if got := tt.link(tt.pos); got != tt.want {
+			t.Errorf("link(%v) = %q; want %q", tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestLinkRenderers(t *testing.T) {
+	link := func(pos w.Position) string { return "x://" + pos.Filename }
+	l := w.List{Warnings: []error{
+		&w.Warning{Code: "C1", Msg: "1w", Pos: w.Position{Filename: "f", Line: 2}},
+		&w.Warning{Msg: "2w", Pos: w.Position{Filename: "g", Line: 3, Column: 4}},
+		warning("3w"),
+	}}
+	tests := []struct {
+		r    w.Renderer
+		want string
+	}{
+		{w.Text{Link: link}, "warnings:\n" +
+			"\x1b]8;;x://f\x1b\\f:2\x1b]8;;\x1b\\: [C1] 1w\n" +
+			"\x1b]8;;x://g\x1b\\g:3:4\x1b]8;;\x1b\\: 2w\n3w\n"},
+		{w.Positional{Link: link}, "warning: 3w\n" +
+			"\x1b]8;;x://f\x1b\\f:2\x1b]8;;\x1b\\: warning: [C1] 1w\n" +
+			"\x1b]8;;x://g\x1b\\g:3:4\x1b]8;;\x1b\\: warning: 2w\n"},
+		{w.Pager{Link: link}, "warning: 3w\n" +
+			"\nf (1):\n  \x1b]8;;x://f\x1b\\2\x1b]8;;\x1b\\: warning: [C1] 1w\n" +
+			"\ng (1):\n  \x1b]8;;x://g\x1b\\3:4\x1b]8;;\x1b\\: warning: 2w\n"},
+	}
+	for _, tt := range tests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.r.Render(b, l); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%T: Render() = %q; want %q", tt.r, b, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package lsp converts between warnings and Language Server Protocol
+// diagnostics, as used in textDocument/publishDiagnostics notifications.
+package lsp // import "gopkg.in/warnings.v0/lsp"
+
+import "gopkg.in/warnings.v0"
+
+// DiagnosticSeverity is the severity of a Diagnostic.
+type DiagnosticSeverity int
+
+// Diagnostic severities, as defined by the protocol.
+const (
+	SeverityError       DiagnosticSeverity = 1
+	SeverityWarning     DiagnosticSeverity = 2
+	SeverityInformation DiagnosticSeverity = 3
+	SeverityHint        DiagnosticSeverity = 4
+)
+
+// Position is a zero-based position in a text document. Character counts
+// UTF-16 code units, whereas warnings.Position.Column counts bytes; see
+// Character and Column to convert between them.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a range in a text document.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic is a protocol diagnostic.
+type Diagnostic struct {
+	Range    Range              `json:"range"`
+	Severity DiagnosticSeverity `json:"severity,omitempty"`
+	Code     string             `json:"code,omitempty"`
+	Source   string             `json:"source,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// FromError converts err to a Diagnostic. For a *warnings.Warning, its code,
+// severity and position are used; the message doesn't include the position.
+// A fatal error always has SeverityError.
+//
+// Without the text of the document, the byte column is used as the
+// character offset, which is only exact if the line is ASCII up to the
+// column; use Character to correct Range when the text is available.
+func FromError(err error, fatal bool) Diagnostic {
+	d := Diagnostic{Severity: SeverityWarning, Message: err.Error()}
+	if w, ok := warnings.AsWarning(err); ok {
+		nw := *w
+		nw.Pos = warnings.Position{}
+		d.Message = nw.Error()
+		d.Code = w.Code
+		d.Severity = severity(w.Severity)
+		d.Range = rangeOf(w.Pos)
+	}
+	if fatal {
+		d.Severity = SeverityError
+	}
+	return d
+}
+
+// FromList converts the warnings in l followed by the fatal errors, if any, to
+// Diagnostics. Use l.GroupBy(warnings.ByFile) to obtain the diagnostics per
+// document.
+func FromList(l warnings.List) []Diagnostic {
+	var ds []Diagnostic
+	for _, err := range l.Warnings {
+		ds = append(ds, FromError(err, false))
+	}
+	for _, err := range l.FatalErrors() {
+		ds = append(ds, FromError(err, true))
+	}
+	return ds
+}
+
+// ToWarning converts d to a *warnings.Warning for the document with the
+// given filename. As for FromError, the character offset is used as the byte
+// column; use Column to correct it when the text is available.
+func ToWarning(d Diagnostic, filename string) *warnings.Warning {
+	w := &warnings.Warning{
+		Code: d.Code,
+		Msg:  d.Message,
+		Pos: warnings.Position{
+			Filename: filename,
+			Line:     d.Range.Start.Line + 1,
+			Column:   d.Range.Start.Character + 1,
+		},
+	}
+	switch d.Severity {
+	case SeverityError:
+		w.Severity = warnings.Error
+	case SeverityInformation, SeverityHint:
+		w.Severity = warnings.Info
+	}
+	return w
+}
+
+func severity(s warnings.Severity) DiagnosticSeverity {
+	switch {
+	case s >= warnings.Error:
+		return SeverityError
+	case s <= warnings.Info:
+		return SeverityInformation
+	}
+	return SeverityWarning
+}
+
+// Character returns the zero-based offset in UTF-16 code units of the
+// one-based byte column in the given line of text, as used in a Position. A
+// column past the end of line is counted as ASCII; a column in the middle of
+// a character counts the whole character.
+func Character(line string, column int) int {
+	n := 0
+	for i, r := range line {
+		if i >= column-1 {
+			return n
+		}
+		if r >= 0x10000 {
+			n++ // surrogate pair
+		}
+		n++
+	}
+	if column-1 > len(line) {
+		return n + column - 1 - len(line)
+	}
+	return n
+}
+
+// Column returns the one-based byte column of the zero-based offset in
+// UTF-16 code units in the given line of text; it is the inverse of
+// Character.
+func Column(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i + 1
+		}
+		if r >= 0x10000 {
+			n++ // surrogate pair
+		}
+		n++
+	}
+	if character > n {
+		return len(line) + character - n + 1
+	}
+	return len(line) + 1
+}
+
+func rangeOf(p warnings.Position) Range {
+	if !p.IsValid() {
+		return Range{}
+	}
+	pos := Position{Line: p.Line - 1}
+	if p.Column > 0 {
+		pos.Character = p.Column - 1
+	}
+	return Range{Start: pos, End: pos}
+}
@@ -0,0 +1,70 @@
+package lsp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/lsp"
+)
+
+func TestFromList(t *testing.T) {
+	l := warnings.List{
+		Warnings: []error{
+			&warnings.CausedError{
+				Err: &warnings.Warning{Code: "C1", Severity: warnings.Info, Msg: "msg",
+					Pos: warnings.Position{Filename: "f", Line: 3, Column: 5}},
+				Cause: errors.New("cause"),
+			},
+			errors.New("plain"),
+		},
+		Fatal: &warnings.Warning{Msg: "fatal", Pos: warnings.Position{Line: 1}},
+	}
+	data, err := json.Marshal(lsp.FromList(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[` +
+		`{"range":{"start":{"line":2,"character":4},"end":{"line":2,"character":4}},"severity":3,"code":"C1","message":"msg"},` +
+		`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"severity":2,"message":"plain"},` +
+		`{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}},"severity":1,"message":"fatal"}]`
+	if string(data) != want {
+		t.Errorf("FromList() = %s; want %s", data, want)
+	}
+}
+
+func TestToWarning(t *testing.T) {
+	w := &warnings.Warning{Code: "C1", Severity: warnings.Error, Msg: "msg",
+		Pos: warnings.Position{Filename: "f", Line: 3, Column: 5}}
+	got := lsp.ToWarning(lsp.FromError(w, false), "f")
+	if !reflect.DeepEqual(got, w) {
+		t.Errorf("ToWarning(FromError(%#v)) = %#v", w, got)
+	}
+}
+
+func TestCharacter(t *testing.T) {
+	tests := []struct {
+		line      string
+		column    int
+		character int
+	}{
+		{"abc", 1, 0},
+		{"abc", 3, 2},
+		{"abc", 4, 3},
+		{"abc", 6, 5},
+		{"é = 1", 4, 2},          // 2 bytes, 1 code unit
+		{"世界 = 1", 8, 3},         // 3 bytes, 1 code unit each
+		{"\U0001F600 = 1", 6, 3}, // 4 bytes, 2 code units
+		{"", 1, 0},
+	}
+	for _, tt := range tests {
+		if got := lsp.Character(tt.line, tt.column); got != tt.character {
+			t.Errorf("Character(%q, %d) = %d; want %d", tt.line, tt.column, got, tt.character)
+		}
+		if got := lsp.Column(tt.line, tt.character); got != tt.column {
+			t.Errorf("Column(%q, %d) = %d; want %d", tt.line, tt.character, got, tt.column)
+		}
+	}
+}
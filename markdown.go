@@ -0,0 +1,74 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// markdownEscaper escapes text for use in Markdown table cells and list items.
+var markdownEscaper = strings.NewReplacer("\n", " ", "|", `\|`, "*", `\*`,
+	"_", `\_`, "`", "\\`")
+
+// WriteMarkdown writes l to w as a Markdown report, suitable e.g. for a pull
+// request comment or a CI job summary. The report starts with the fatal error,
+// if any, followed by a table summarizing the warnings per code and a section
+// listing the warnings for each code, most frequent codes first. Warning.Count
+// is taken into account.
+func (l List) WriteMarkdown(w io.Writer) error {
+	b := bytes.NewBuffer(nil)
+	for _, err := range l.FatalErrors() {
+		fmt.Fprintf(b, "**Fatal:** %s\n\n", markdownEscaper.Replace(err.Error()))
+	}
+	if len(l.Warnings) == 0 {
+		fmt.Fprintf(b, "No warnings.\n")
+		_, err := w.Write(b.Bytes())
+		return err
+	}
+	groups := List{Warnings: l.Warnings}.GroupBy(codeOf)
+	type section struct {
+		code  string
+		count int
+	}
+	var sections []section
+	total := 0
+	for code, g := range groups {
+		n := 0
+		for _, err := range g.Warnings {
+			n += countOf(err)
+		}
+		sections = append(sections, section{code, n})
+		total += n
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].count != sections[j].count {
+			return sections[i].count > sections[j].count
+		}
+		return sections[i].code < sections[j].code
+	})
+	label := func(code string) string {
+		if code == "" {
+			return "(no code)"
+		}
+		return markdownEscaper.Replace(code)
+	}
+	fmt.Fprintf(b, "| Code | Warnings |\n| --- | ---: |\n")
+	for _, s := range sections {
+		fmt.Fprintf(b, "| %s | %d |\n", label(s.code), s.count)
+	}
+	fmt.Fprintf(b, "| **Total** | **%d** |\n", total)
+	for _, s := range sections {
+		fmt.Fprintf(b, "\n### %s (%d)\n\n", label(s.code), s.count)
+		for _, err := range groups[s.code].Warnings {
+			fmt.Fprintf(b, "- %s", markdownEscaper.Replace(err.Error()))
+			if n := countOf(err); n > 1 {
+				fmt.Fprintf(b, " (%d times)", n)
+			}
+			fmt.Fprintln(b)
+		}
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,61 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var markdownTests = []struct {
+	l    w.List
+	want string
+}{
+	{w.List{}, "No warnings.\n"},
+	{w.List{Fatal: fatal("1f")}, "**Fatal:** 1f\n\nNo warnings.\n"},
+	{
+		w.List{
+			Warnings: []error{
+				&w.Warning{Code: "C2", Msg: "a|b"},
+				&w.Warning{Code: "C1", Msg: "c", Pos: w.Position{Filename: "f", Line: 1}},
+				warning("1w"),
+				&w.Warning{Code: "C1", Msg: "d", Count: 2},
+			},
+			Fatal: fatal("2f"),
+		},
+		`**Fatal:** 2f
+
+| Code | Warnings |
+| --- | ---: |
+| C1 | 3 |
+| (no code) | 1 |
+| C2 | 1 |
+| **Total** | **5** |
+
+### C1 (3)
+
+- f:1: c
+- d (2 times)
+
+### (no code) (1)
+
+- 1w
+
+### C2 (1)
+
+- a\|b
+`,
+	},
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	for _, tt := range markdownTests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.l.WriteMarkdown(b); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("WriteMarkdown() = %q; want %q", b, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package warnings
+
+import (
+	"path"
+	"regexp"
+)
+
+// A Matcher reports whether an error matches some criteria. Matchers are
+// composed using And, Or and Not, and can be used wherever a func(error) bool
+// is expected, e.g. as Collector.Ignore, as a Filter, or in test assertions
+// (see warningstest.AssertMatch):
+//
+//	c.Ignore = warnings.And(
+//		warnings.MatchCode("CFG*"),
+//		warnings.Not(warnings.MatchSeverity(warnings.Error)),
+//	)
+type Matcher func(err error) bool
+
+// MatchCode returns a Matcher matching errors whose code (see Warning.Code)
+// matches pattern, in the syntax of path.Match, e.g. "CFG*".
+func MatchCode(pattern string) Matcher {
+	return func(err error) bool {
+		ok, _ := path.Match(pattern, codeOf(err))
+		return ok
+	}
+}
+
+// MatchSeverity returns a Matcher matching errors with severity s or higher
+// (see SeverityOf).
+func MatchSeverity(s Severity) Matcher {
+	return func(err error) bool { return SeverityOf(err) >= s }
+}
+
+// MatchMessage returns a Matcher matching errors whose message, excluding
+// the position of a *Warning, matches re.
+func MatchMessage(re *regexp.Regexp) Matcher {
+	return func(err error) bool { return re.MatchString(messageOf(err)) }
+}
+
+// And returns a Matcher matching errors matched by all of ms.
+func And(ms ...Matcher) Matcher {
+	return func(err error) bool {
+		for _, m := range ms {
+			if !m(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Matcher matching errors matched by any of ms.
+func Or(ms ...Matcher) Matcher {
+	return func(err error) bool {
+		for _, m := range ms {
+			if m(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Matcher matching errors not matched by m.
+func Not(m Matcher) Matcher {
+	return func(err error) bool { return !m(err) }
+}
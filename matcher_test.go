@@ -0,0 +1,57 @@
+package warnings_test
+
+import (
+	"regexp"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestMatcher(t *testing.T) {
+	cfg := &w.Warning{Code: "CFG012", Msg: "unknown key", Pos: w.Position{"f", 1, 0}}
+	cfgErr := &w.Warning{Code: "CFG013", Severity: w.Error, Msg: "bad value"}
+	net := &w.Warning{Code: "NET001", Severity: w.Info, Msg: "slow"}
+	plain := warning("plain key")
+	tests := []struct {
+		name string
+		m    w.Matcher
+		want []bool // for cfg, cfgErr, net, plain
+	}{
+		{"code", w.MatchCode("CFG*"), []bool{true, true, false, false}},
+		{"code exact", w.MatchCode("NET001"), []bool{false, false, true, false}},
+		{"code bad pattern", w.MatchCode("["), []bool{false, false, false, false}},
+		{"severity", w.MatchSeverity(w.Warn), []bool{true, true, false, true}},
+		{"message", w.MatchMessage(regexp.MustCompile(`key$`)),
+			[]bool{true, false, false, true}},
+		{"message no position", w.MatchMessage(regexp.MustCompile(`^f:`)),
+			[]bool{false, false, false, false}},
+		{"and", w.And(w.MatchCode("CFG*"), w.Not(w.MatchSeverity(w.Error))),
+			[]bool{true, false, false, false}},
+		{"or", w.Or(w.MatchCode("NET*"), w.MatchSeverity(w.Error)),
+			[]bool{false, true, true, false}},
+		{"and empty", w.And(), []bool{true, true, true, true}},
+		{"or empty", w.Or(), []bool{false, false, false, false}},
+	}
+	for _, tt := range tests {
+		for i, err := range []error{cfg, cfgErr, net, plain} {
+			if got := tt.m(err); got != tt.want[i] {
+				t.Errorf("%s: match(%v) = %v; want %v", tt.name, err, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestMatcherIgnore(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Ignore = w.MatchMessage(regexp.MustCompile(`^noise`))
+	c.Collect(warning("noise 1"))
+	c.Collect(warning("1w"))
+	l := w.List{Warnings: c.Warnings()}
+	if len(l.Warnings) != 1 {
+		t.Errorf("Warnings() = %v; want [1w]", l.Warnings)
+	}
+	m := w.Not(w.MatchMessage(regexp.MustCompile(`1`)))
+	if got := l.Filter(w.Filter(m)); len(got.Warnings) != 0 {
+		t.Errorf("Filter() = %v; want no warnings", got)
+	}
+}
@@ -0,0 +1,41 @@
+package warnings
+
+// A FatalPolicy determines how fatal errors are handled when merging Lists
+// that hold more than one.
+type FatalPolicy int
+
+// Fatal policies. The zero value is KeepAllFatals.
+const (
+	KeepAllFatals  FatalPolicy = iota // keep all of them (see List.AddFatal)
+	KeepFirstFatal                    // keep the first one, drop the others
+	JoinFatals                        // join them into one, like errors.Join
+)
+
+// Merge adds the warnings and fatal errors of o to l, handling multiple
+// fatal errors according to p. The counts of o are added up.
+func (l *List) Merge(o List, p FatalPolicy) {
+	l.Warnings = append(l.Warnings, o.Warnings...)
+	for _, err := range o.FatalErrors() {
+		l.mergeFatal(err, p)
+	}
+	l.Drained += o.Drained
+	l.Omitted += o.Omitted
+	l.Expired += o.Expired
+	l.Total += o.Total
+}
+
+// mergeFatal adds the fatal error err to l according to p.
+func (l *List) mergeFatal(err error, p FatalPolicy) {
+	switch {
+	case err == nil:
+	case l.Fatal == nil || p == KeepAllFatals:
+		l.AddFatal(err)
+	case p == JoinFatals:
+		errs := l.FatalErrors()
+		if je, ok := l.Fatal.(*joinedError); ok && je.msg == "" && len(l.Fatals) == 0 {
+			errs = je.errs // joined before
+		}
+		errs = append(errs[:len(errs):len(errs)], err)
+		l.SetFatal(&joinedError{errs: errs})
+	}
+}
@@ -0,0 +1,66 @@
+package warnings_test
+
+import (
+	"errors"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestMerge(t *testing.T) {
+	f1, f2, f3 := fatal("1f"), fatal("2f"), fatal("3f")
+	lists := []w.List{
+		{Warnings: []error{warning("1w")}, Fatal: f1, Omitted: 1},
+		{Warnings: []error{warning("2w")}},
+		{Fatal: f2, Fatals: []error{f2, f3}},
+	}
+	tests := []struct {
+		p      w.FatalPolicy
+		fatals int
+		text   string
+	}{
+		{w.KeepAllFatals, 3, "fatal:\n1f\n2f\n3f\n"},
+		{w.KeepFirstFatal, 1, "fatal:\n1f\n"},
+		{w.JoinFatals, 1, "fatal:\n1f\n2f\n3f\n"},
+	}
+	for _, tt := range tests {
+		var l w.List
+		for _, o := range lists {
+			l.Merge(o, tt.p)
+		}
+		if len(l.Warnings) != 2 || l.Omitted != 1 {
+			t.Errorf("Merge(%v) = %v; want 2 warnings and 1 omitted", tt.p, l)
+		}
+		if got := len(l.FatalErrors()); got != tt.fatals {
+			t.Errorf("Merge(%v) kept %d fatal errors; want %d", tt.p, got, tt.fatals)
+		}
+		if got := (w.List{Fatal: l.Fatal, Fatals: l.Fatals}).Error(); got != tt.text {
+			t.Errorf("Merge(%v) fatal = %q; want %q", tt.p, got, tt.text)
+		}
+		if tt.p == w.JoinFatals && !(errors.Is(l.Fatal, f1) && errors.Is(l.Fatal, f3)) {
+			t.Errorf("errors.Is(%v, ...) = false; want true for all joined", l.Fatal)
+		}
+	}
+}
+
+func TestMergeJoinFatals(t *testing.T) {
+	f1, f2, f3 := fatal("1f"), fatal("2f"), fatal("3f")
+	l := w.List{Fatal: f1, Fatals: []error{f1, f2}}
+	l.Merge(w.List{Fatal: f3}, w.JoinFatals)
+	if got := l.FatalErrors(); len(got) != 1 || got[0].Error() != "1f\n2f\n3f" {
+		t.Errorf("FatalErrors() = %q; want 1f, 2f and 3f joined", got)
+	}
+	// Is is called directly, as errors.Is uses Unwrap instead as of Go 1.20.
+	if is, ok := l.Fatal.(interface{ Is(error) bool }); !ok || !is.Is(f2) {
+		t.Errorf("%v.Is(2f) = false; want true", l.Fatal)
+	}
+}
+
+func TestAggregatorFatalPolicy(t *testing.T) {
+	a := w.Aggregator{FatalPolicy: w.KeepFirstFatal}
+	a.Add("a", w.List{Fatal: fatal("1f")})
+	a.Add("b", w.List{Fatal: fatal("2f")})
+	if got := a.List().FatalErrors(); len(got) != 1 || got[0].Error() != "1f" {
+		t.Errorf("FatalErrors() = %v; want [1f]", got)
+	}
+}
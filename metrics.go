@@ -0,0 +1,38 @@
+package warnings
+
+// Metrics counts the errors passed to a Collector, including those that
+// didn't make it into the collected List, as returned by Collector.Metrics.
+type Metrics struct {
+	Collected  int // errors passed to Collect, after any middleware
+	Suppressed int // warnings dropped by Ignore
+	Deduped    int // warnings dropped by DedupWindow or merged by OncePerCode
+	Truncated  int // warnings dropped because MaxWarnings was reached
+	Late       int // errors dropped after the time set by WithTimeout
+	// Codes holds the number of errors collected per code; errors without
+	// a code are counted under "-".
+	Codes map[string]int
+}
+
+// Metrics returns a snapshot of the metrics of c, e.g. so that a program can
+// report how many warnings were filtered out.
+func (c *Collector) Metrics() Metrics {
+	m := c.metrics
+	m.Codes = make(map[string]int, len(c.metrics.Codes))
+	for code, n := range c.metrics.Codes {
+		m.Codes[code] = n
+	}
+	return m
+}
+
+// countMetrics counts err in the metrics of c.
+func (c *Collector) countMetrics(err error) {
+	code := codeOf(err)
+	if code == "" {
+		code = "-"
+	}
+	if c.metrics.Codes == nil {
+		c.metrics.Codes = make(map[string]int)
+	}
+	c.metrics.Collected++
+	c.metrics.Codes[code]++
+}
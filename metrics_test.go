@@ -0,0 +1,40 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestMetrics(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := w.NewCollector(isFatalStructured)
+	c.Now = func() time.Time { return now }
+	c.Ignore = w.MatchCode("IGN")
+	c.DedupWindow = time.Minute
+	c.OncePerCode = true
+	c.MaxWarnings = 2
+	for _, err := range []error{
+		&w.Warning{Code: "IGN", Msg: "1w"},
+		&w.Warning{Code: "C1", Msg: "2w"},
+		&w.Warning{Code: "C1", Msg: "2w"}, // dropped by DedupWindow
+		&w.Warning{Code: "C1", Msg: "3w"}, // merged by OncePerCode
+		&w.Warning{Msg: "4w"},
+		&w.Warning{Msg: "5w"}, // dropped by MaxWarnings
+		fatal("6f"),
+	} {
+		c.Collect(err)
+	}
+	want := w.Metrics{Collected: 7, Suppressed: 1, Deduped: 2, Truncated: 1,
+		Codes: map[string]int{"IGN": 1, "C1": 3, "-": 3}}
+	got := c.Metrics()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics() = %+v; want %+v", got, want)
+	}
+	got.Codes["C1"] = 0
+	if c.Metrics().Codes["C1"] != 3 {
+		t.Errorf("Metrics() returned the Collector's map")
+	}
+}
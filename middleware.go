@@ -0,0 +1,32 @@
+package warnings
+
+// A CollectFunc collects an error, with the same result as Collector.Collect.
+type CollectFunc func(err error) error
+
+// A Middleware decorates the collection of errors, e.g. for redaction, rate
+// limiting or metrics. It returns a CollectFunc that may modify an error
+// before passing it to next, or drop it by returning nil without calling
+// next:
+//
+//	redact := func(next warnings.CollectFunc) warnings.CollectFunc {
+//		return func(err error) error {
+//			return next(redacted(err))
+//		}
+//	}
+type Middleware func(next CollectFunc) CollectFunc
+
+// Use adds middleware through which errors pass before being collected by c.
+// The first middleware added is the outermost one, i.e. it sees each error
+// first.
+func (c *Collector) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// chain returns the middleware of c applied to its collect method with attrs.
+func (c *Collector) chain(attrs []Attr) CollectFunc {
+	next := CollectFunc(func(err error) error { return c.collect(err, attrs) })
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next
+}
@@ -0,0 +1,65 @@
+package warnings_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestUse(t *testing.T) {
+	var trace []string
+	record := func(name string) w.Middleware {
+		return func(next w.CollectFunc) w.CollectFunc {
+			return func(err error) error {
+				trace = append(trace, name+":"+err.Error())
+				return next(err)
+			}
+		}
+	}
+	redact := func(next w.CollectFunc) w.CollectFunc {
+		return func(err error) error {
+			if _, ok := err.(warn); ok {
+				err = warning(strings.Replace(err.Error(), "secret", "***", -1))
+			}
+			return next(err)
+		}
+	}
+	drop := func(next w.CollectFunc) w.CollectFunc {
+		return func(err error) error {
+			if strings.HasPrefix(err.Error(), "drop") {
+				return nil
+			}
+			return next(err)
+		}
+	}
+	c := w.NewCollector(isFatal)
+	c.Use(record("a"), redact, drop)
+	c.Use(record("b"))
+	c.Collect(warning("1w secret"))
+	c.Collect(warning("drop me"))
+	if err := c.Collect(fatal("3f")); err == nil {
+		t.Errorf("Collect(fatal) = nil; want an error")
+	}
+	wantTrace := []string{"a:1w secret", "b:1w ***", "a:drop me", "a:3f", "b:3f"}
+	if !reflect.DeepEqual(trace, wantTrace) {
+		t.Errorf("trace = %q; want %q", trace, wantTrace)
+	}
+	f, warns := w.Split(c.Done())
+	if len(warns) != 1 || warns[0].Error() != "1w ***" {
+		t.Errorf("Split() warnings = %v; want [1w ***]", warns)
+	}
+	if f == nil || f.Error() != "3f" {
+		t.Errorf("Split() fatal = %v; want 3f", f)
+	}
+}
+
+func TestUseAttrs(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.Use(func(next w.CollectFunc) w.CollectFunc { return next })
+	c.Collect(&w.Warning{Msg: "1w"}, w.Field("k", "v"))
+	if got := w.AttrsOf(c.Warnings()[0]); len(got) != 1 || got[0].Key != "k" {
+		t.Errorf("AttrsOf() = %v; want [k=v]", got)
+	}
+}
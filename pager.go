@@ -0,0 +1,142 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pager is a Renderer for long reports read in a terminal pager, as in
+// "tool validate | less -R". Errors are sorted as for Positional and grouped
+// into one section per file, headed by the file name and the number of
+// errors in it; errors without a file name come first, without a header:
+//
+//	config.yaml (2):
+//	  3:1: warning: [CFG012] message
+//	  7: error: another message, wrapped at Width and continued
+//	            with indentation
+type Pager struct {
+	// Width, if > 0, is the width at which messages are wrapped, e.g. the
+	// width of the terminal.
+	Width int
+	// Color set to true means that severities are colored (see
+	// SeverityLevel.Color), as passed through by "less -R".
+	Color bool
+	// Hyperlinks set to true means that file names are emitted as OSC 8
+	// hyperlinks to their file:// URLs, for terminals supporting them.
+	Hyperlinks bool
+	// Link, if set, links the line and column of errors likewise, e.g. to
+	// open them in an editor (see EditorLink).
+	Link LinkFunc
+}
+
+// escapes matches the ANSI escape sequences emitted by renderers: CSI
+// sequences such as colors, and OSC sequences such as hyperlinks.
+var escapes = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]|\x1b\\][^\x1b\x07]*(\x07|\x1b\\\\)")
+
+// visibleLen returns the number of runes in s, not counting escapes.
+func visibleLen(s string) int {
+	if strings.IndexByte(s, 0x1b) >= 0 {
+		s = escapes.ReplaceAllString(s, "")
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// minWrapWidth is the minimum width of wrapped message text, however deep
+// its indentation.
+const minWrapWidth = 20
+
+// Render implements Renderer.
+func (p Pager) Render(w io.Writer, l List) error {
+	b := bytes.NewBuffer(nil)
+	items := positionalItems(l)
+	for i := 0; i < len(items); {
+		file := items[i].pos.Filename
+		j := i
+		for j < len(items) && items[j].pos.Filename == file {
+			j++
+		}
+		indent := ""
+		if file != "" {
+			if b.Len() > 0 {
+				fmt.Fprintln(b)
+			}
+			name := file
+			if p.Hyperlinks {
+				name = hyperlink(FileLink(Position{Filename: file}), file)
+			}
+			fmt.Fprintf(b, "%s (%d):\n", name, j-i)
+			indent = "  "
+		}
+		for _, it := range items[i:j] {
+			p.writeItem(b, indent, it)
+		}
+		i = j
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeItem writes it to b, indented by indent.
+func (p Pager) writeItem(b *bytes.Buffer, indent string, it positionalItem) {
+	var loc, plainLoc string
+	if it.pos.IsValid() {
+		lc := Position{Line: it.pos.Line, Column: it.pos.Column}
+		plainLoc = lc.String() + ": "
+		loc = plainLoc
+		if p.Link != nil {
+			if u := p.Link(it.pos); u != "" {
+				loc = hyperlink(u, lc.String()) + ": "
+			}
+		}
+	}
+	sev := SeverityOf(it.err)
+	if it.fatal {
+		sev = Error
+	}
+	label := sev.String()
+	plain := indent + plainLoc + label + ": "
+	if c := sev.Level().Color; p.Color && c != "" {
+		label = c + label + "\x1b[0m"
+	}
+	lines := wrapText(it.message(), p.Width-utf8.RuneCountInString(plain))
+	fmt.Fprintf(b, "%s%s%s: %s\n", indent, loc, label, lines[0])
+	cont := strings.Repeat(" ", utf8.RuneCountInString(plain))
+	for _, line := range lines[1:] {
+		fmt.Fprintf(b, "%s%s\n", cont, line)
+	}
+}
+
+// wrapText splits s into lines of at most width runes (but at least
+// minWrapWidth), breaking at spaces; words longer than that are kept whole.
+// Escape sequences don't count towards the width. If width is <= 0, s is only
+// split at newlines.
+func wrapText(s string, width int) []string {
+	if width <= 0 {
+		return strings.Split(s, "\n")
+	}
+	if width < minWrapWidth {
+		width = minWrapWidth
+	}
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line, n := "", 0
+		for _, word := range strings.Fields(para) {
+			wn := visibleLen(word)
+			switch {
+			case n == 0:
+				line, n = word, wn
+			case n+1+wn <= width:
+				line, n = line+" "+word, n+1+wn
+			default:
+				lines = append(lines, line)
+				line, n = word, wn
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,54 @@
+package warnings_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var pagerList = w.List{
+	Warnings: []error{
+		&w.Warning{Msg: "1w", Pos: w.Position{Filename: "b", Line: 2}},
+		&w.Warning{Code: "C2", Msg: "the second warning has a long message",
+			Pos: w.Position{Filename: "a", Line: 10, Column: 1}},
+		warning("3w"),
+	},
+	Fatal: &w.Warning{Msg: "4f", Pos: w.Position{Filename: "b", Line: 3}},
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		p    w.Pager
+		want string
+	}{
+		{w.Pager{}, "warning: 3w\n" +
+			"\na (1):\n  10:1: warning: [C2] the second warning has a long message\n" +
+			"\nb (2):\n  2: warning: 1w\n  3: error: 4f\n"},
+		{w.Pager{Width: 40, Color: true}, "\x1b[33mwarning\x1b[0m: 3w\n" +
+			"\na (1):\n  10:1: \x1b[33mwarning\x1b[0m: [C2] the second warning\n" +
+			"                 has a long message\n" +
+			"\nb (2):\n  2: \x1b[33mwarning\x1b[0m: 1w\n  3: \x1b[31merror\x1b[0m: 4f\n"},
+	}
+	for _, tt := range tests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.p.Render(b, pagerList); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%+v: Render() = %q; want %q", tt.p, b, tt.want)
+		}
+	}
+}
+
+func TestPagerHyperlinks(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	if err := (w.Pager{Hyperlinks: true}).Render(b, pagerList); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "\x1b]8;;file:///") ||
+		!strings.Contains(b.String(), "/a\x1b\\a\x1b]8;;\x1b\\ (1):\n") {
+		t.Errorf("Render() = %q; want hyperlinked file names", b)
+	}
+}
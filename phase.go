@@ -0,0 +1,70 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A Phase is a named part of a collection (such as "parse", "validate" or
+// "apply"), together with the errors collected during it.
+type Phase struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration // zero while the phase is in progress
+	List     List
+}
+
+// Phase ends the current phase, if any, and starts a new one named name.
+// Errors collected before the first call to Phase are not attributed to any
+// phase. Phase mustn't be called after the first fatal error or after Done has
+// been called.
+func (c *Collector) Phase(name string) {
+	if c.done {
+		panic("warnings.Collector already done")
+	}
+	c.start()
+	c.endPhase()
+	c.phases = append(c.phases, Phase{Name: name, Start: c.now()})
+}
+
+// Phases returns the phases started so far.
+func (c *Collector) Phases() []Phase {
+	return append([]Phase(nil), c.phases...)
+}
+
+func (c *Collector) endPhase() {
+	if len(c.phases) == 0 {
+		return
+	}
+	p := &c.phases[len(c.phases)-1]
+	if p.Duration == 0 {
+		p.Duration = c.now().Sub(p.Start)
+	}
+}
+
+func (c *Collector) phaseCollect(err error, fatal bool) {
+	if len(c.phases) == 0 {
+		return
+	}
+	p := &c.phases[len(c.phases)-1]
+	if fatal {
+		p.List.Fatal = err
+		c.endPhase()
+	} else {
+		p.List.Warnings = append(p.List.Warnings, err)
+	}
+}
+
+// WritePhases writes phases (such as returned by Collector.Phases) to w, one
+// section per phase in order, each headed by the phase name and duration.
+func WritePhases(w io.Writer, phases []Phase) error {
+	b := bytes.NewBuffer(nil)
+	for _, p := range phases {
+		fmt.Fprintf(b, "%s (%v):\n", p.Name, p.Duration)
+		writeIndented(b, p.List)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
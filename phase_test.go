@@ -0,0 +1,51 @@
+package warnings_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestPhases(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("0w"))
+	c.Phase("parse")
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	c.Phase("validate")
+	c.Phase("apply")
+	f := fatal("3f")
+	c.Collect(f)
+	c.Done()
+	phases := c.Phases()
+	var names []string
+	for _, p := range phases {
+		names = append(names, p.Name)
+	}
+	if want := []string{"parse", "validate", "apply"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Phases() names = %v; want %v", names, want)
+	}
+	wantLists := []w.List{
+		{Warnings: []error{warning("1w"), warning("2w")}},
+		{},
+		{Fatal: f},
+	}
+	for i, p := range phases {
+		if !reflect.DeepEqual(p.List, wantLists[i]) {
+			t.Errorf("phase %q List = %v; want %v", p.Name, p.List, wantLists[i])
+		}
+	}
+	b := bytes.NewBuffer(nil)
+	if err := w.WritePhases(b, phases); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"parse (", "  warnings:\n  1w\n  2w\n",
+		"validate (", "apply (", "  fatal:\n  3f\n"} {
+		if !strings.Contains(b.String(), s) {
+			t.Errorf("WritePhases() = %q; want it to contain %q", b, s)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Plain is a Renderer wrapping another one to produce plain ASCII output, for
+// screen readers and for environments logging raw bytes: escape sequences
+// (colors and hyperlinks) are removed, and the output is passed through
+// PlainText.
+type Plain struct {
+	// Renderer is the Renderer wrapped; if nil, Text{} is used.
+	Renderer Renderer
+}
+
+// Render implements Renderer.
+func (p Plain) Render(w io.Writer, l List) error {
+	r := p.Renderer
+	if r == nil {
+		r = Text{}
+	}
+	b := bytes.NewBuffer(nil)
+	if err := r.Render(b, l); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, PlainText(b.String()))
+	return err
+}
+
+// plainRunes maps common typographic runes to ASCII replacements.
+var plainRunes = map[rune]string{
+	'•': "*", '·': "*", '‣': "*", '◦': "*",
+	'–': "-", '—': "-", '−': "-",
+	'‘': "'", '’': "'", '“': `"`, '”': `"`,
+	'…': "...", '→': "->", '←': "<-", '⇒': "=>",
+	'✓': "ok", '✔': "ok", '✗': "x", '✘': "x",
+	'\u00a0': " ", // no-break space
+}
+
+// PlainText returns s as plain ASCII: escape sequences are removed, common
+// typographic runes (such as bullets, dashes and quotes) are replaced by ASCII
+// equivalents, and any other non-ASCII runes and control characters other
+// than newlines and tabs are escaped as in Go string literals, e.g. "é" as
+// `\u00e9`.
+func PlainText(s string) string {
+	s = escapes.ReplaceAllString(s, "")
+	var b strings.Builder
+	for len(s) > 0 {
+		r, n := utf8.DecodeRuneInString(s)
+		switch {
+		case r == utf8.RuneError && n == 1:
+			fmt.Fprintf(&b, `\x%02x`, s[0])
+		case r == '\n' || r == '\t' || r >= ' ' && r < utf8.RuneSelf && r != 0x7f:
+			b.WriteRune(r)
+		case plainRunes[r] != "":
+			b.WriteString(plainRunes[r])
+		case r < 0x10000:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			fmt.Fprintf(&b, `\U%08x`, r)
+		}
+		s = s[n:]
+	}
+	return b.String()
+}
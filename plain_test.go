@@ -0,0 +1,40 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"plain\ttext\n", "plain\ttext\n"},
+		{"\x1b[33mwarning\x1b[0m: \x1b]8;;file:///f\x1b\\f:1\x1b]8;;\x1b\\",
+			"warning: f:1"},
+		{"• “quoted” — café…", `* "quoted" - caf\u00e9...`},
+		{"bell\a \xff 😀", `bell\u0007 \xff \U0001f600`},
+	}
+	for _, tt := range tests {
+		if got := w.PlainText(tt.s); got != tt.want {
+			t.Errorf("PlainText(%q) = %q; want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPlain(t *testing.T) {
+	l := w.List{Warnings: []error{
+		&w.Warning{Msg: "naïve", Pos: w.Position{Filename: "f", Line: 1}},
+	}}
+	b := bytes.NewBuffer(nil)
+	r := w.Plain{Renderer: w.Pager{Color: true, Hyperlinks: true}}
+	if err := r.Render(b, l); err != nil {
+		t.Fatal(err)
+	}
+	if want := "f (1):\n  1: warning: na\\u00efve\n"; b.String() != want {
+		t.Errorf("Render() = %q; want %q", b, want)
+	}
+}
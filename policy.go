@@ -0,0 +1,169 @@
+package warnings
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Policy is a compiler-style configuration for the treatment of warnings,
+// typically set from command line flags using RegisterFlags.
+type Policy struct {
+	Werror   bool            // treat warnings as fatal errors
+	Disabled map[string]bool // codes of warnings to ignore
+	Max      int             // maximum number of warnings to keep; 0 means no limit
+	Quiet    bool            // ignore all warnings
+	// Thresholds maps codes to the minimum severity of warnings with that
+	// code; warnings of lower severity (see SeverityOf) are ignored.
+	Thresholds map[string]Severity
+}
+
+// ignored reports whether the warning err is ignored by p.
+func (p *Policy) ignored(err error) bool {
+	code := codeOf(err)
+	if min, ok := p.Thresholds[code]; ok && code != "" && SeverityOf(err) < min {
+		return true
+	}
+	return p.Quiet || code != "" && p.Disabled[code]
+}
+
+// Filter returns a Filter dropping the warnings ignored by p, for applying p
+// at render time rather than when collecting.
+func (p *Policy) Filter() Filter {
+	return func(err error) bool { return !p.ignored(err) }
+}
+
+// NewCollector returns a new Collector configured according to p. It uses
+// isFatal (if nil, severity, as for Collector.IsFatal) to distinguish between
+// warnings and fatal errors; if Werror is set, all warnings that aren't
+// ignored are fatal as well.
+func (p *Policy) NewCollector(isFatal func(error) bool) *Collector {
+	isFatal = orSeverity(isFatal)
+	return &Collector{
+		IsFatal: func(err error) bool {
+			return isFatal(err) || p.Werror && !p.ignored(err)
+		},
+		Ignore:      p.ignored,
+		MaxWarnings: p.Max,
+	}
+}
+
+// RegisterFlags registers the following flags for p on fs:
+//
+//	-Werror       treat warnings as errors
+//	-Wno=CODE,... disable warnings with the given codes (repeatable)
+//	-Wmax=N       keep at most N warnings
+//	-Wquiet       disable all warnings
+//	-Wmin=CODE=SEVERITY,...
+//	              ignore warnings with the given codes below the given
+//	              severity (repeatable)
+//
+// Additionally, a flag -Wno-CODE is registered for each of codes.
+func (p *Policy) RegisterFlags(fs *flag.FlagSet, codes ...string) {
+	fs.BoolVar(&p.Werror, "Werror", p.Werror, "treat warnings as errors")
+	fs.Var((*codesValue)(p), "Wno", "disable warnings with the given `codes` (comma-separated)")
+	fs.IntVar(&p.Max, "Wmax", p.Max, "keep at most `N` warnings (0 means no limit)")
+	fs.BoolVar(&p.Quiet, "Wquiet", p.Quiet, "disable all warnings")
+	fs.Var((*thresholdsValue)(p), "Wmin", "ignore warnings below a minimum severity per code (`CODE=SEVERITY`, comma-separated)")
+	for _, code := range codes {
+		fs.Var(&codeValue{p, code}, "Wno-"+code, "disable "+code+" warnings")
+	}
+}
+
+func (p *Policy) disable(code string) {
+	if p.Disabled == nil {
+		p.Disabled = make(map[string]bool)
+	}
+	p.Disabled[code] = true
+}
+
+// codesValue is the flag.Value for -Wno.
+type codesValue Policy
+
+func (v *codesValue) String() string {
+	if v == nil {
+		return ""
+	}
+	var codes []string
+	for code, disabled := range v.Disabled {
+		if disabled {
+			codes = append(codes, code)
+		}
+	}
+	return strings.Join(codes, ",")
+}
+
+func (v *codesValue) Set(s string) error {
+	for _, code := range strings.Split(s, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			(*Policy)(v).disable(code)
+		}
+	}
+	return nil
+}
+
+// thresholdsValue is the flag.Value for -Wmin.
+type thresholdsValue Policy
+
+func (v *thresholdsValue) String() string {
+	if v == nil {
+		return ""
+	}
+	var ts []string
+	for code, min := range v.Thresholds {
+		ts = append(ts, code+"="+min.String())
+	}
+	sort.Strings(ts)
+	return strings.Join(ts, ",")
+}
+
+func (v *thresholdsValue) Set(s string) error {
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		}
+		i := strings.LastIndex(t, "=")
+		if i < 0 {
+			return fmt.Errorf("warnings: invalid threshold %q (want CODE=SEVERITY)", t)
+		}
+		var min Severity
+		if err := min.UnmarshalText([]byte(t[i+1:])); err != nil {
+			return err
+		}
+		if v.Thresholds == nil {
+			v.Thresholds = make(map[string]Severity)
+		}
+		v.Thresholds[t[:i]] = min
+	}
+	return nil
+}
+
+// codeValue is the boolean flag.Value for -Wno-CODE.
+type codeValue struct {
+	p    *Policy
+	code string
+}
+
+func (v *codeValue) IsBoolFlag() bool { return true }
+
+func (v *codeValue) String() string {
+	if v == nil || v.p == nil {
+		return "false"
+	}
+	return strconv.FormatBool(v.p.Disabled[v.code])
+}
+
+func (v *codeValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if b {
+		v.p.disable(v.code)
+	} else if v.p.Disabled != nil {
+		delete(v.p.Disabled, v.code)
+	}
+	return nil
+}
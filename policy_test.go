@@ -0,0 +1,101 @@
+package warnings_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var (
+	policyC1 = &w.Warning{Code: "C1", Msg: "c1"}
+	policyC2 = &w.Warning{Code: "C2", Msg: "c2"}
+	policyC3 = &w.Warning{Code: "C3", Msg: "c3"}
+	policyC4 = &w.Warning{Code: "C4", Severity: w.Error, Msg: "c4"}
+)
+
+var policyTests = []struct {
+	args  []string
+	fatal error
+	warns []error
+}{
+	{nil, nil, []error{policyC1, policyC2, policyC3, policyC4}},
+	{[]string{"-Wno=C1,C3"}, nil, []error{policyC2, policyC4}},
+	{[]string{"-Wno", "C1", "-Wno-C2"}, nil, []error{policyC3, policyC4}},
+	{[]string{"-Wmax=2"}, nil, []error{policyC1, policyC2}},
+	{[]string{"-Wquiet"}, nil, nil},
+	{[]string{"-Wmin=C1=error,C4=error", "-Wmin", "C2=info"}, nil,
+		[]error{policyC2, policyC3, policyC4}},
+	{[]string{"-Werror"}, policyC1, nil},
+	{[]string{"-Werror", "-Wno-C1"}, policyC2, nil},
+	{[]string{"-Werror", "-Wquiet"}, nil, nil},
+}
+
+func TestPolicyFlags(t *testing.T) {
+	for _, tt := range policyTests {
+		var p w.Policy
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		p.RegisterFlags(fs, "C1", "C2")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		c := p.NewCollector(isFatalStructured)
+		for _, err := range []error{policyC1, policyC2, policyC3, policyC4} {
+			if c.Collect(err) != nil {
+				break
+			}
+		}
+		fatal, warns := w.Split(c.Done())
+		if fatal != tt.fatal || !reflect.DeepEqual(warns, tt.warns) {
+			t.Errorf("%v: Done() = %v, %v; want %v, %v", tt.args, fatal, warns,
+				tt.fatal, tt.warns)
+		}
+	}
+}
+
+func TestPolicyThresholdsFlag(t *testing.T) {
+	var p w.Policy
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	p.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-Wmin=C1=fatal"}); err == nil {
+		t.Errorf("Parse(-Wmin=C1=fatal) = nil; want error")
+	}
+	if err := fs.Parse([]string{"-Wmin=C1=error,C2=info"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fs.Lookup("Wmin").Value.String(), "C1=error,C2=info"; got != want {
+		t.Errorf("-Wmin = %q; want %q", got, want)
+	}
+}
+
+func TestPolicyFilter(t *testing.T) {
+	p := w.Policy{Thresholds: map[string]w.Severity{"C1": w.Error}}
+	l := w.List{Warnings: []error{policyC1, policyC2}}
+	got := l.Filter(p.Filter())
+	if want := []error{policyC2}; !reflect.DeepEqual(got.Warnings, want) ||
+		got.Omitted != 1 {
+		t.Errorf("Filter() = %v (%d omitted); want %v (1 omitted)",
+			got.Warnings, got.Omitted, want)
+	}
+}
+
+func TestNilIsFatal(t *testing.T) {
+	warn, errSev := &w.Warning{Msg: "1w"}, &w.Warning{Severity: w.Error, Msg: "2f"}
+	classifiers := map[string]func(error) bool{
+		"Policy":      (&w.Policy{}).NewCollector(nil).IsFatal,
+		"VersionGate": w.VersionGate(nil, "v1", nil),
+		"Sunset":      w.Sunset(nil, "v1", nil),
+	}
+	for name, isFatal := range classifiers {
+		if isFatal(warn) || !isFatal(errSev) {
+			t.Errorf("%s(nil) doesn't classify by severity", name)
+		}
+	}
+	l := w.FromErrors([]error{warn, errSev}, nil)
+	if len(l.Warnings) != 1 || l.Fatal != errSev {
+		t.Errorf("FromErrors(nil) = %#v; want 1w, fatal 2f", l)
+	}
+}
@@ -0,0 +1,92 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Positional is a Renderer for compiler-style output, as parsed natively by
+// editors and CI annotators: one line per error, in the form
+// "file:line:col: severity: [CODE] message", sorted by position. Fatal
+// errors have severity "error"; errors without a position are rendered
+// without the position prefix, before the others.
+type Positional struct {
+	// Link, if set, emits positions as OSC 8 hyperlinks (e.g. using FileLink
+	// or EditorLink), so that users of modern terminals can click straight to
+	// the offending line.
+	Link LinkFunc
+}
+
+// positionalItem is an error rendered by Positional.
+type positionalItem struct {
+	err   error
+	pos   Position
+	fatal bool
+}
+
+// positionalItems returns the errors in l sorted by position; errors at the
+// same position keep the order they were collected in, fatal errors last.
+func positionalItems(l List) []positionalItem {
+	var items []positionalItem
+	add := func(err error, fatal bool) {
+		it := positionalItem{err: err, fatal: fatal}
+		if w, ok := AsWarning(err); ok {
+			it.pos = w.Pos
+		}
+		items = append(items, it)
+	}
+	for _, err := range l.Warnings {
+		add(err, false)
+	}
+	for _, err := range l.FatalErrors() {
+		add(err, true)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		p, q := items[i].pos, items[j].pos
+		switch {
+		case p.Filename != q.Filename:
+			return p.Filename < q.Filename
+		case p.Line != q.Line:
+			return p.Line < q.Line
+		}
+		return p.Column < q.Column
+	})
+	return items
+}
+
+// prefix returns the "file:line:col: severity: " prefix of it, with the
+// position linked using link, if set.
+func (it positionalItem) prefix(link LinkFunc) string {
+	sev := SeverityOf(it.err).String()
+	if it.fatal {
+		sev = Error.String()
+	}
+	if it.pos.Filename == "" && !it.pos.IsValid() {
+		return sev + ": "
+	}
+	return linkPosition(link, it.pos) + ": " + sev + ": "
+}
+
+// message returns the message of it, preceded by its code, if any.
+func (it positionalItem) message() string {
+	s := messageOf(it.err)
+	if code := codeOf(it.err); code != "" {
+		s = "[" + code + "] " + s
+	}
+	if n := countOf(it.err); n > 1 {
+		s += fmt.Sprintf(" (%d more times)", n-1)
+	}
+	return s
+}
+
+// Render implements Renderer.
+func (p Positional) Render(w io.Writer, l List) error {
+	b := bytes.NewBuffer(nil)
+	for _, it := range positionalItems(l) {
+		fmt.Fprintf(b, "%s%s\n", it.prefix(p.Link), it.message())
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
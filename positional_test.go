@@ -0,0 +1,43 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var positionalTests = []struct {
+	l    w.List
+	want string
+}{
+	{w.List{}, ""},
+	{
+		w.List{
+			Warnings: []error{
+				&w.Warning{Msg: "1w", Pos: w.Position{"b", 2, 0}},
+				&w.Warning{Code: "C2", Msg: "2w", Pos: w.Position{"a", 10, 1}},
+				&w.Warning{Severity: w.Info, Msg: "3i", Pos: w.Position{"a", 2, 5}, Count: 2},
+				warning("4w"),
+			},
+			Fatal: &w.Warning{Msg: "5f", Pos: w.Position{"b", 2, 0}},
+		},
+		"warning: 4w\n" +
+			"a:2:5: info: 3i (1 more times)\n" +
+			"a:10:1: warning: [C2] 2w\n" +
+			"b:2: warning: 1w\n" +
+			"b:2: error: 5f\n",
+	},
+}
+
+func TestPositional(t *testing.T) {
+	for _, tt := range positionalTests {
+		b := bytes.NewBuffer(nil)
+		if err := (w.Positional{}).Render(b, tt.l); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Render() = %q; want %q", b, tt.want)
+		}
+	}
+}
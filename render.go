@@ -0,0 +1,314 @@
+package warnings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// A Renderer writes the errors in a List to a Writer in some format.
+type Renderer interface {
+	Render(w io.Writer, l List) error
+}
+
+// The RendererFunc type is an adapter to allow the use of ordinary functions
+// as Renderers.
+type RendererFunc func(w io.Writer, l List) error
+
+// Render calls f(w, l).
+func (f RendererFunc) Render(w io.Writer, l List) error { return f(w, l) }
+
+// Text is a Renderer for human readable text, as returned by List.Error.
+type Text struct {
+	// Filters, if any, select the warnings to render (see List.Filter).
+	Filters []Filter
+	// Chronological set to true means that the errors are rendered in the
+	// order they were collected, each prefixed by its severity (or "fatal"),
+	// rather than the fatal error first.
+	Chronological bool
+	// Compact set to true means that the errors are rendered on a single
+	// line, as returned by List.Compact; it overrides Chronological.
+	Compact bool
+	// NoTrailingNewline set to true means that the final newline is omitted.
+	NoTrailingNewline bool
+	// ByPriority set to true means that the warnings are rendered in order of
+	// decreasing priority (see Warning.Priority), and otherwise in the order
+	// they were collected.
+	ByPriority bool
+	// Verbosity selects the amount of detail rendered; see Verbosity.
+	Verbosity Verbosity
+	// NoCodes set to true means that the codes of warnings are omitted;
+	// by default, they precede the message in brackets, as in
+	// "file:1: [CFG012] message".
+	NoCodes bool
+	// Docs, if set, holds documentation URLs per code; the report then ends
+	// with a line "[CODE] more info: URL" for each distinct code rendered
+	// (except in Compact mode).
+	Docs DocRegistry
+	// Width, if > 0, is the width at which long messages are wrapped (e.g.
+	// as returned by TerminalWidth), continuing on lines indented by two
+	// spaces; it doesn't apply in Compact mode, or to errors formatted with
+	// %+v at Debug verbosity.
+	Width int
+	// Link, if set, emits the positions of warnings as OSC 8 hyperlinks (see
+	// LinkFunc).
+	Link LinkFunc
+}
+
+// Verbosity is the amount of detail rendered by Text. The zero value is
+// Normal.
+type Verbosity int
+
+// Verbosity levels, in increasing order.
+const (
+	Quiet   Verbosity = iota - 1 // the fatal error only
+	Normal                       // the fatal error and warnings
+	Verbose                      // additionally, hints
+	Debug                        // additionally, causes formatted with %+v (e.g. with stack traces)
+)
+
+// Render implements Renderer.
+func (t Text) Render(w io.Writer, l List) error {
+	l = l.Filter(t.Filters...)
+	if t.Verbosity <= Quiet {
+		l = List{Fatal: l.Fatal, Fatals: l.Fatals}
+	}
+	if t.ByPriority {
+		l.Warnings = append([]error(nil), l.Warnings...)
+		sort.SliceStable(l.Warnings, func(i, j int) bool {
+			return PriorityOf(l.Warnings[i]) > PriorityOf(l.Warnings[j])
+		})
+	}
+	var s string
+	switch {
+	case t.Compact:
+		s = l.compact(t) + "\n"
+	case t.Chronological:
+		s = l.chronological(t)
+	default:
+		s = l.text(t)
+	}
+	if !t.Compact && len(t.Docs) > 0 {
+		b := bytes.NewBufferString(s)
+		t.Docs.writeDocs(b, l)
+		s = b.String()
+	}
+	if t.NoTrailingNewline {
+		s = strings.TrimSuffix(s, "\n")
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// Compact returns the errors in l on a single line, for embedding in other
+// error messages, e.g. "fatal: X (warnings: a; b; c)".
+func (l List) Compact() string {
+	return l.compact(Text{})
+}
+
+// compact returns the errors in l as returned by Compact, with the options
+// of t.
+func (l List) compact(t Text) string {
+	var warns []string
+	for _, err := range l.Warnings {
+		if n := countOf(err); n > 1 {
+			warns = append(warns, fmt.Sprintf("%s (%d more times)",
+				t.itemText(err), n-1))
+		} else {
+			warns = append(warns, t.itemText(err))
+		}
+	}
+	if n := l.Drained + l.Omitted + l.Expired; n > 0 {
+		warns = append(warns, fmt.Sprintf("%d more", n))
+	}
+	var ws string
+	switch l.count() {
+	case 0:
+	case 1:
+		ws = "warning: " + strings.Join(warns, "; ")
+	default:
+		ws = "warnings: " + strings.Join(warns, "; ")
+	}
+	if l.Fatal == nil {
+		return ws
+	}
+	var fatals []string
+	for _, err := range l.FatalErrors() {
+		fatals = append(fatals, t.itemText(err))
+	}
+	fs := "fatal: " + strings.Join(fatals, "; ")
+	if ws == "" {
+		return fs
+	}
+	return fs + " (" + ws + ")"
+}
+
+// chronological returns the errors in l in the order they were collected,
+// each prefixed by its severity, with the options of t.
+func (l List) chronological(t Text) string {
+	b := bytes.NewBuffer(nil)
+	if l.Drained > 0 {
+		fmt.Fprintf(b, "(%d earlier warning(s) already written)\n", l.Drained)
+	}
+	if l.Expired > 0 {
+		fmt.Fprintf(b, "(%d earlier warning(s) expired)\n", l.Expired)
+	}
+	for _, err := range l.Warnings {
+		t.writeItem(b, SeverityOf(err).String()+": ", err)
+	}
+	if l.Omitted > 0 {
+		fmt.Fprintf(b, "(%d more warning(s) omitted)\n", l.Omitted)
+	}
+	for _, err := range l.FatalErrors() {
+		t.writeItem(b, "fatal: ", err)
+	}
+	return b.String()
+}
+
+// itemText returns the text of err, including its code unless NoCodes is
+// set, prefixed by its component, if any.
+func (t Text) itemText(err error) string {
+	s := t.codedText(err)
+	if comp := ByComponent(err); comp != "" {
+		s = comp + ": " + s
+	}
+	return s
+}
+
+// codedText returns the text of err, including its code unless NoCodes is
+// set, and with its position linked using Link, if set.
+func (t Text) codedText(err error) string {
+	w, ok := AsWarning(err)
+	if !ok || (t.NoCodes || w.Code == "") && t.Link == nil {
+		return err.Error()
+	}
+	s := w.message()
+	if !t.NoCodes && w.Code != "" {
+		s = "[" + w.Code + "] " + s
+	}
+	if w.Pos.Filename == "" && !w.Pos.IsValid() {
+		return s
+	}
+	return linkPosition(t.Link, w.Pos) + ": " + s
+}
+
+// writeItem writes err to b on a line starting with prefix, followed by the
+// details selected by t.Verbosity on separate lines.
+func (t Text) writeItem(b *bytes.Buffer, prefix string, err error) {
+	v := t.Verbosity
+	w, _ := AsWarning(err)
+	var count string
+	if n := countOf(err); n > 1 {
+		count = fmt.Sprintf(" (%d more times)", n-1)
+	}
+	if v >= Debug && w == nil {
+		fmt.Fprintf(b, "%s%+v%s\n", prefix, err, count)
+	} else {
+		t.writeWrapped(b, prefix+t.itemText(err)+count)
+	}
+	if w == nil {
+		return
+	}
+	if v >= Verbose && w.Hint != "" {
+		t.writeWrapped(b, "  hint: "+w.Hint)
+	}
+	if v >= Debug && w.Err != nil {
+		fmt.Fprintf(b, "  cause: %+v\n", w.Err)
+	}
+}
+
+// writeWrapped writes s to b, wrapped at t.Width, if set, with continuation
+// lines indented by two more spaces than s.
+func (t Text) writeWrapped(b *bytes.Buffer, s string) {
+	if t.Width <= 0 {
+		fmt.Fprintln(b, s)
+		return
+	}
+	text := strings.TrimLeft(s, " ")
+	indent := s[:len(s)-len(text)]
+	for i, line := range wrapText(text, t.Width-len(indent)-2) {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		fmt.Fprintf(b, "%s%s\n", indent, line)
+	}
+}
+
+// NDJSON is a Renderer for newline delimited JSON: one JSON object (as for
+// Warning.MarshalJSON) per line for each warning, followed by one for the
+// fatal error, if any, which has the additional field "fatal": true.
+type NDJSON struct{}
+
+// ndjsonRecord is a line written by NDJSON.
+type ndjsonRecord struct {
+	jsonWarning
+	Fatal bool `json:"fatal,omitempty"`
+}
+
+// Render implements Renderer.
+func (NDJSON) Render(w io.Writer, l List) error {
+	enc := json.NewEncoder(w)
+	for _, err := range l.Warnings {
+		if err := enc.Encode(ndjsonRecord{toJSONWarning(err), false}); err != nil {
+			return err
+		}
+	}
+	for _, err := range l.FatalErrors() {
+		if err := enc.Encode(ndjsonRecord{toJSONWarning(err), true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// methodRenderer returns a Renderer calling a List method such as
+// List.WriteCSV.
+func methodRenderer(f func(l List, w io.Writer) error) Renderer {
+	return RendererFunc(func(w io.Writer, l List) error { return f(l, w) })
+}
+
+// NDJSONHook returns a function for use as Collector.Hook that writes each
+// collected error to w as a line of NDJSON (see NDJSON), as soon as it is
+// collected. Errors writing to w are ignored.
+func NDJSONHook(w io.Writer) func(Event) {
+	enc := json.NewEncoder(w)
+	return func(e Event) {
+		enc.Encode(ndjsonRecord{toJSONWarning(e.Err), e.Fatal})
+	}
+}
+
+// renderers maps the names accepted by RendererByName to Renderers.
+var renderers = map[string]Renderer{
+	"text":       Text{},
+	"json":       NDJSON{},
+	"ndjson":     NDJSON{},
+	"csv":        methodRenderer(List.WriteCSV),
+	"tsv":        methodRenderer(List.WriteTSV),
+	"tap":        methodRenderer(List.WriteTAP),
+	"checkstyle": methodRenderer(List.WriteCheckstyle),
+	"markdown":   methodRenderer(List.WriteMarkdown),
+	"positional": Positional{},
+	"gcc":        GCC{},
+	"pager":      Pager{},
+	"plain":      Plain{},
+}
+
+// RendererByName returns the Renderer for the output format name, e.g. as
+// given by an -output flag: one of "text", "json" (or "ndjson"), "csv", "tsv",
+// "tap", "checkstyle", "markdown", "positional", "gcc", "pager" and "plain".
+func RendererByName(name string) (Renderer, error) {
+	r, ok := renderers[name]
+	if !ok {
+		names := make([]string, 0, len(renderers))
+		for n := range renderers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("warnings: unknown output format %q (want one of %s)",
+			name, strings.Join(names, ", "))
+	}
+	return r, nil
+}
@@ -0,0 +1,260 @@
+package warnings_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var renderList = w.List{
+	Warnings: []error{
+		&w.Warning{Code: "C1", Msg: "msg", Pos: w.Position{Filename: "f", Line: 1}},
+	},
+	Fatal: fatal("2f"),
+}
+
+var rendererTests = []struct {
+	name string
+	want string
+}{
+	{"text", "fatal:\n2f\nwarning:\nf:1: [C1] msg\n"},
+	{"json", `{"code":"C1","message":"msg","file":"f","line":1}` + "\n" +
+		`{"message":"2f","fatal":true}` + "\n"},
+	{"tap", "TAP version 13\n1..2\nok 1 - f:1: msg # SKIP warning\nnot ok 2 - 2f\n"},
+}
+
+func TestRendererByName(t *testing.T) {
+	for _, tt := range rendererTests {
+		r, err := w.RendererByName(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := bytes.NewBuffer(nil)
+		if err := r.Render(b, renderList); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: Render() = %q; want %q", tt.name, b, tt.want)
+		}
+	}
+	if _, err := w.RendererByName("xml"); err == nil {
+		t.Errorf("RendererByName(%q) succeeded; want error", "xml")
+	}
+}
+
+func TestNDJSONHook(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	c := w.NewCollector(isFatalStructured)
+	c.Hook = w.NDJSONHook(b)
+	for _, err := range renderList.Warnings {
+		c.Collect(err)
+		if b.Len() == 0 {
+			t.Fatalf("nothing written after Collect(%v)", err)
+		}
+	}
+	c.Collect(renderList.Fatal)
+	if want := rendererTests[1].want; b.String() != want {
+		t.Errorf("NDJSONHook wrote %q; want %q", b, want)
+	}
+}
+
+func TestTextChronological(t *testing.T) {
+	l := w.List{
+		Warnings: []error{
+			warning("1w"),
+			&w.Warning{Severity: w.Error, Msg: "2w", Count: 2},
+		},
+		Fatal:   fatal("3f"),
+		Drained: 1,
+		Omitted: 1,
+	}
+	b := bytes.NewBuffer(nil)
+	if err := (w.Text{Chronological: true}).Render(b, l); err != nil {
+		t.Fatal(err)
+	}
+	want := "(1 earlier warning(s) already written)\nwarning: 1w\n" +
+		"error: 2w (1 more times)\n(1 more warning(s) omitted)\nfatal: 3f\n"
+	if b.String() != want {
+		t.Errorf("Render() = %q; want %q", b, want)
+	}
+}
+
+var compactTests = []struct {
+	l    w.List
+	want string
+}{
+	{w.List{}, ""},
+	{w.List{Fatal: fatal("1f")}, "fatal: 1f"},
+	{w.List{Warnings: []error{warning("1w")}}, "warning: 1w"},
+	{w.List{Warnings: []error{warning("1w"), &w.Warning{Msg: "2w", Count: 3}},
+		Fatal: fatal("3f")}, "fatal: 3f (warnings: 1w; 2w (2 more times))"},
+	{w.List{Warnings: []error{warning("1w")}, Drained: 1, Omitted: 2},
+		"warnings: 1w; 3 more"},
+}
+
+func TestCompact(t *testing.T) {
+	for _, tt := range compactTests {
+		if got := tt.l.Compact(); got != tt.want {
+			t.Errorf("Compact() = %q; want %q", got, tt.want)
+		}
+	}
+}
+
+var textTests = []struct {
+	r    w.Text
+	want string
+}{
+	{w.Text{}, "fatal:\n2f\nwarning:\nf:1: [C1] msg\n"},
+	{w.Text{NoCodes: true}, "fatal:\n2f\nwarning:\nf:1: msg\n"},
+	{w.Text{NoTrailingNewline: true}, "fatal:\n2f\nwarning:\nf:1: [C1] msg"},
+	{w.Text{Compact: true}, "fatal: 2f (warning: f:1: [C1] msg)\n"},
+	{w.Text{Compact: true, NoCodes: true}, "fatal: 2f (warning: f:1: msg)\n"},
+	{w.Text{Compact: true, Chronological: true, NoTrailingNewline: true},
+		"fatal: 2f (warning: f:1: [C1] msg)"},
+	{w.Text{Chronological: true, NoTrailingNewline: true, NoCodes: true},
+		"warning: f:1: msg\nfatal: 2f"},
+}
+
+func TestTextOptions(t *testing.T) {
+	for _, tt := range textTests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.r.Render(b, renderList); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%+v: Render() = %q; want %q", tt.r, b, tt.want)
+		}
+	}
+}
+
+// stackErr is an error printing a fake stack trace with %+v.
+type stackErr string
+
+func (e stackErr) Error() string { return string(e) }
+
+func (e stackErr) Format(s fmt.State, verb rune) {
+	io.WriteString(s, string(e))
+	if s.Flag('+') {
+		io.WriteString(s, "\n\tstack")
+	}
+}
+
+var verbosityList = w.List{
+	Warnings: []error{
+		&w.Warning{Msg: "1w", Hint: "fix it", Err: stackErr("cause")},
+		stackErr("2w"),
+	},
+	Fatal: fatal("3f"),
+}
+
+var verbosityTests = []struct {
+	r    w.Text
+	want string
+}{
+	{w.Text{Verbosity: w.Quiet}, "fatal:\n3f\n"},
+	{w.Text{}, "fatal:\n3f\nwarnings:\n1w: cause\n2w\n"},
+	{w.Text{Verbosity: w.Verbose},
+		"fatal:\n3f\nwarnings:\n1w: cause\n  hint: fix it\n2w\n"},
+	{w.Text{Verbosity: w.Debug}, "fatal:\n3f\nwarnings:\n1w: cause\n" +
+		"  hint: fix it\n  cause: cause\n\tstack\n2w\n\tstack\n"},
+	{w.Text{Verbosity: w.Verbose, Chronological: true},
+		"warning: 1w: cause\n  hint: fix it\nwarning: 2w\nfatal: 3f\n"},
+}
+
+func TestTextVerbosity(t *testing.T) {
+	for _, tt := range verbosityTests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.r.Render(b, verbosityList); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%+v: Render() = %q; want %q", tt.r, b, tt.want)
+		}
+	}
+}
+
+func TestCollectorReport(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"))
+	c.Collect(fatal("2f"))
+	c.Finish()
+	reports := []struct {
+		r    w.Renderer
+		want string
+	}{
+		{nil, "fatal:\n2f\nwarning:\n1w\n"},
+		{w.Text{Verbosity: w.Quiet}, "fatal:\n2f\n"},
+		{w.Text{Compact: true}, "fatal: 2f (warning: 1w)\n"},
+	}
+	for _, tt := range reports {
+		b := bytes.NewBuffer(nil)
+		if err := c.Report(b, tt.r); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Report(%#v) = %q; want %q", tt.r, b, tt.want)
+		}
+	}
+}
+
+func TestCollectorReportNotDone(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Report() before Finish didn't panic")
+		}
+	}()
+	w.NewCollector(isFatal).Report(bytes.NewBuffer(nil), nil)
+}
+
+func TestCodes(t *testing.T) {
+	l := w.List{Warnings: []error{
+		&w.Warning{Code: "CFG012", Msg: "1w"},
+		&w.Warning{Code: "CFG013", Msg: "2w", Pos: w.Position{"f", 3, 4}},
+		&w.Warning{Msg: "3w", Pos: w.Position{"f", 5, 0}},
+	}}
+	want := "warnings:\n[CFG012] 1w\nf:3:4: [CFG013] 2w\nf:5: 3w\n"
+	if l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+}
+
+func TestTextByPriority(t *testing.T) {
+	l := w.List{Warnings: []error{
+		warning("1w"),
+		&w.Warning{Msg: "2w", Priority: 1},
+		&w.Warning{Msg: "3w", Priority: 5},
+		&w.Warning{Msg: "4w", Priority: 1},
+	}}
+	b := bytes.NewBuffer(nil)
+	if err := (w.Text{ByPriority: true}).Render(b, l); err != nil {
+		t.Fatal(err)
+	}
+	if want := "warnings:\n3w\n2w\n4w\n1w\n"; b.String() != want {
+		t.Errorf("Render() = %q; want %q", b, want)
+	}
+	if got := l.Warnings[0].Error(); got != "1w" {
+		t.Errorf("Render() reordered l.Warnings: first is %q", got)
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	l := w.List{Warnings: []error{&w.Warning{
+		Msg:  "a message much longer than the width of the terminal",
+		Pos:  w.Position{Filename: "f", Line: 1},
+		Hint: "split the message into several shorter ones",
+	}}}
+	want := "warning:\nf:1: a message much longer\n" +
+		"  than the width of the terminal\n" +
+		"  hint: split the message into\n" +
+		"    several shorter ones\n"
+	b := bytes.NewBuffer(nil)
+	if err := (w.Text{Width: 32, Verbosity: w.Verbose}).Render(b, l); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != want {
+		t.Errorf("Render() = %q; want %q", b, want)
+	}
+}
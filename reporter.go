@@ -0,0 +1,171 @@
+package warnings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// reportBatch is the maximum number of warnings passed to a single call of
+// Reporter.ReportWarning.
+const reportBatch = 100
+
+// A Reporter receives the errors collected by a Collector, e.g. for
+// forwarding them to an error tracker.
+//
+// The methods of a Reporter are called asynchronously from a separate
+// goroutine, and never concurrently for a single Collector. Collect blocks
+// only when the Reporter falls far behind; the first fatal error and Done wait
+// for all pending reports to be delivered.
+type Reporter interface {
+	// ReportWarning reports a batch of warnings, in collection order.
+	ReportWarning(warnings []error)
+	// ReportFatal reports the fatal error; it is called after reporting all
+	// preceding warnings.
+	ReportFatal(fatal error)
+}
+
+// reporting delivers errors to a Reporter on a separate goroutine. It is a
+// Flusher, registered with RegisterSink while delivering.
+type reporting struct {
+	ch         chan reportItem
+	done       chan struct{}
+	unregister func()
+
+	mu     sync.Mutex // guards closing ch against Flush
+	closed bool
+}
+
+// reportItem is an event to report, or a Flush request.
+type reportItem struct {
+	e       Event
+	flushed chan struct{} // closed once reached, for Flush
+}
+
+func (c *Collector) report(err error, fatal bool) {
+	if c.Reporter == nil {
+		return
+	}
+	if c.reports == nil {
+		c.reports = &reporting{
+			ch:   make(chan reportItem, reportBatch),
+			done: make(chan struct{}),
+		}
+		c.reports.unregister = RegisterSink(c.reports)
+		go deliver(c.Reporter, c.reports)
+	}
+	c.reports.ch <- reportItem{e: Event{Err: err, Fatal: fatal}}
+	if fatal {
+		c.closeReports()
+	}
+}
+
+// closeReports waits for all pending reports to be delivered.
+func (c *Collector) closeReports() {
+	if c.reports == nil {
+		return
+	}
+	c.reports.unregister()
+	c.reports.mu.Lock()
+	c.reports.closed = true
+	close(c.reports.ch)
+	c.reports.mu.Unlock()
+	<-c.reports.done
+}
+
+// Flush waits until all errors reported so far have been delivered.
+func (r *reporting) Flush() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		<-r.done
+		return
+	}
+	flushed := make(chan struct{})
+	r.ch <- reportItem{flushed: flushed}
+	r.mu.Unlock()
+	<-flushed
+}
+
+func deliver(r Reporter, reports *reporting) {
+	defer close(reports.done)
+	var batch []error
+	for it := range reports.ch {
+		if it.flushed != nil {
+			if len(batch) > 0 {
+				r.ReportWarning(batch)
+				batch = nil
+			}
+			close(it.flushed)
+			continue
+		}
+		e := it.e
+		if !e.Fatal {
+			batch = append(batch, e.Err)
+		}
+		if len(batch) > 0 &&
+			(e.Fatal || len(reports.ch) == 0 || len(batch) >= reportBatch) {
+			r.ReportWarning(batch)
+			batch = nil
+		}
+		if e.Fatal {
+			r.ReportFatal(e.Err)
+		}
+	}
+}
+
+// A WebhookReporter is a Reporter that posts the errors reported to a URL as
+// JSON, in the format of List.MarshalJSON; each batch of warnings and the
+// fatal error are posted as a separate List.
+type WebhookReporter struct {
+	URL string
+	// Client is the HTTP client used for posting; if nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+	// ErrorLog specifies an optional logger for errors posting to URL; if
+	// nil, logging is done via the log package's standard logger.
+	ErrorLog *log.Logger
+}
+
+// ReportWarning implements Reporter.
+func (r *WebhookReporter) ReportWarning(warnings []error) {
+	r.post(List{Warnings: warnings})
+}
+
+// ReportFatal implements Reporter.
+func (r *WebhookReporter) ReportFatal(fatal error) {
+	r.post(List{Fatal: fatal})
+}
+
+func (r *WebhookReporter) post(l List) {
+	if err := r.doPost(l); err != nil {
+		if r.ErrorLog != nil {
+			r.ErrorLog.Print(err)
+		} else {
+			log.Print(err)
+		}
+	}
+}
+
+func (r *WebhookReporter) doPost(l List) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Post(r.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("warnings: posting to webhook: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("warnings: posting to webhook: %s", resp.Status)
+	}
+	return nil
+}
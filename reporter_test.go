@@ -0,0 +1,75 @@
+package warnings_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+type recordingReporter struct {
+	warnings []error
+	fatal    error
+}
+
+func (r *recordingReporter) ReportWarning(warnings []error) {
+	r.warnings = append(r.warnings, warnings...)
+}
+
+func (r *recordingReporter) ReportFatal(fatal error) { r.fatal = fatal }
+
+func TestReporter(t *testing.T) {
+	for _, tt := range collectorTests {
+		c := tt.collector
+		r := &recordingReporter{}
+		c.Reporter = r
+		for _, warn := range tt.warnings {
+			c.Collect(warn)
+		}
+		if tt.fatal != nil {
+			c.Collect(tt.fatal)
+			// reports must be delivered even if Done isn't called
+		} else {
+			c.Done()
+		}
+		if !reflect.DeepEqual(r.warnings, omitNils(tt.warnings)) ||
+			r.fatal != tt.fatal {
+			t.Errorf("reported %v, %v; want %v, %v", r.warnings, r.fatal,
+				omitNils(tt.warnings), tt.fatal)
+		}
+	}
+}
+
+func TestWebhookReporter(t *testing.T) {
+	var mu sync.Mutex
+	var msgs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var l w.List
+		if err := json.NewDecoder(req.Body).Decode(&l); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		for _, err := range l.Warnings {
+			msgs = append(msgs, "warning "+err.Error())
+		}
+		if l.Fatal != nil {
+			msgs = append(msgs, "fatal "+l.Fatal.Error())
+		}
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	c := w.NewCollector(isFatal)
+	c.Reporter = &w.WebhookReporter{URL: srv.URL}
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	c.Collect(fatal("3f"))
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"warning 1w", "warning 2w", "fatal 3f"}; !reflect.DeepEqual(msgs, want) {
+		t.Errorf("posted %v; want %v", msgs, want)
+	}
+}
@@ -0,0 +1,47 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A Section is a labeled List nested in a parent List, such as the errors
+// for one of several documents validated together. It is stored among the
+// warnings of the parent; sections can be nested further.
+type Section struct {
+	Label string
+	List  List
+}
+
+// Error implements the error interface. The message consists of the label
+// followed by the lines of s.List.Error(), indented by two spaces.
+func (s *Section) Error() string {
+	b := bytes.NewBuffer(nil)
+	fmt.Fprintf(b, "%s:\n", s.Label)
+	writeIndented(b, s.List)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Unwrap returns s.List followed by the errors in it (see List.ToErrors), so
+// that errors.Is and errors.As find both the List and the errors nested in
+// the section.
+func (s *Section) Unwrap() []error {
+	return append([]error{s.List}, s.List.ToErrors(true)...)
+}
+
+// Is reports whether s.List or any error in it matches target.
+func (s *Section) Is(target error) bool {
+	return (&joinedError{errs: s.Unwrap()}).Is(target)
+}
+
+// As finds the first of s.List and the errors in it that matches target.
+func (s *Section) As(target interface{}) bool {
+	return (&joinedError{errs: s.Unwrap()}).As(target)
+}
+
+// AddSection appends a Section with the given label and List to the warnings
+// in l.
+func (l *List) AddSection(label string, sl List) {
+	l.AddWarning(&Section{Label: label, List: sl})
+}
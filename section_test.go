@@ -0,0 +1,66 @@
+package warnings_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func sectionList() w.List {
+	var doc2 w.List
+	doc2.AddWarning(warning("2w"))
+	doc2.AddSection("part", w.List{Warnings: []error{warning("3w")}})
+	var l w.List
+	l.AddSection("doc1", w.List{Warnings: []error{warning("1w")}})
+	l.AddSection("doc2", doc2)
+	l.SetFatal(fatal("4f"))
+	return l
+}
+
+func TestSection(t *testing.T) {
+	l := sectionList()
+	want := "fatal:\n4f\nwarnings:\ndoc1:\n  warning:\n  1w\ndoc2:\n" +
+		"  warnings:\n  2w\n  part:\n    warning:\n    3w\n"
+	if l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+	var sl w.List
+	if !errors.As(l.Warnings[0], &sl) || len(sl.Warnings) != 1 {
+		t.Errorf("errors.As(section, &List) = %v; want 1w", sl)
+	}
+	var ww warn
+	if !errors.As(l.Warnings[1], &ww) || ww != "2w" {
+		t.Errorf("errors.As(section, &warn) = %q; want 2w", ww)
+	}
+	if !errors.Is(l.Warnings[1], warning("3w")) {
+		t.Errorf("errors.Is(section, 3w) = false; want true for nested sections")
+	}
+}
+
+func TestSectionJSON(t *testing.T) {
+	l := sectionList()
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":1,"warnings":[` +
+		`{"message":"doc1","section":{"warnings":[{"message":"1w"}]}},` +
+		`{"message":"doc2","section":{"warnings":[{"message":"2w"},` +
+		`{"message":"part","section":{"warnings":[{"message":"3w"}]}}]}}],` +
+		`"fatal":{"message":"4f"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s; want %s", data, want)
+	}
+	var l2 w.List
+	if err := json.Unmarshal(data, &l2); err != nil {
+		t.Fatal(err)
+	}
+	if l2.Error() != l.Error() {
+		t.Errorf("round trip Error() = %q; want %q", l2.Error(), l.Error())
+	}
+	if s, ok := l2.Warnings[1].(*w.Section); !ok || s.Label != "doc2" {
+		t.Errorf("round trip Warnings[1] = %#v; want *Section doc2", l2.Warnings[1])
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !plan9
+// +build !plan9
+
+package warnings
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals are the signals handled by FlushOnSignal by default.
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
@@ -0,0 +1,6 @@
+package warnings
+
+import "os"
+
+// shutdownSignals are the signals handled by FlushOnSignal by default.
+var shutdownSignals = []os.Signal{os.Interrupt}
@@ -0,0 +1,69 @@
+//go:build go1.21
+// +build go1.21
+
+package warnings
+
+import (
+	"log/slog"
+	"sort"
+)
+
+// LogValue implements slog.LogValuer; it summarizes l as a group with the
+// number of warnings, their distinct codes (if any), and the fatal error (if
+// any).
+func (l List) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.Int("count", l.count())}
+	if codes := l.codes(); len(codes) > 0 {
+		attrs = append(attrs, slog.Any("codes", codes))
+	}
+	if l.Fatal != nil {
+		attrs = append(attrs, slog.String("fatal", l.Fatal.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer; it represents w as a group with its
+// message (excluding the position), and its code, severity, position, hint
+// and attributes, if set.
+func (w *Warning) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("msg", w.message())}
+	if w.Code != "" {
+		attrs = append(attrs, slog.String("code", w.Code))
+	}
+	if w.Severity != Warn {
+		attrs = append(attrs, slog.String("severity", w.Severity.String()))
+	}
+	if w.Pos.Filename != "" || w.Pos.IsValid() {
+		attrs = append(attrs, slog.String("pos", w.Pos.String()))
+	}
+	if w.Hint != "" {
+		attrs = append(attrs, slog.String("hint", w.Hint))
+	}
+	for _, a := range w.Attrs {
+		attrs = append(attrs, slog.Any(a.Key, a.Value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// codes returns the distinct codes of the warnings in l, in sorted order.
+func (l List) codes() []string {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, err := range l.Warnings {
+		if w, ok := AsWarning(err); ok && w.Code != "" && !seen[w.Code] {
+			seen[w.Code] = true
+			codes = append(codes, w.Code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LogAttr returns a "warnings" attribute summarizing the errors collected so
+// far (see List.LogValue), e.g. for adding to the access log record of a
+// request using a request-scoped Collector:
+//
+//	logger.LogAttrs(ctx, slog.LevelInfo, "request", ..., c.LogAttr())
+func (c *Collector) LogAttr() slog.Attr {
+	return slog.Attr{Key: "warnings", Value: c.l.LogValue()}
+}
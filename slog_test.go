@@ -0,0 +1,61 @@
+//go:build go1.21
+// +build go1.21
+
+package warnings_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestCollectorLogAttr(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.Collect(&w.Warning{Code: "DEP2", Msg: "b"})
+	c.Collect(warning("1w"))
+	c.Collect(&w.Warning{Code: "DEP1", Msg: "a"})
+	c.Collect(&w.Warning{Code: "DEP2", Msg: "c"})
+	b := bytes.NewBuffer(nil)
+	logger := slog.New(slog.NewTextHandler(b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("request", c.LogAttr())
+	want := "level=INFO msg=request warnings.count=4 warnings.codes=\"[DEP1 DEP2]\"\n"
+	if b.String() != want {
+		t.Errorf("log = %q; want %q", b, want)
+	}
+}
+
+func TestListLogValueFatal(t *testing.T) {
+	l := w.List{Fatal: fatal("1f")}
+	got := l.LogValue().Group()
+	if len(got) != 2 || got[1].Key != "fatal" || got[1].Value.String() != "1f" {
+		t.Errorf("LogValue() = %v; want count and fatal", got)
+	}
+}
+
+func TestWarningLogValue(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	logger := slog.New(slog.NewTextHandler(b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"), w.Field("table", "users"), w.Field("row", 3))
+	logger.Warn("collected", "warning", w.WarningsOnly(c.Done())[0])
+	want := "level=WARN msg=collected warning.msg=1w warning.table=users warning.row=3\n"
+	if b.String() != want {
+		t.Errorf("log = %q; want %q", b, want)
+	}
+}
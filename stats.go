@@ -0,0 +1,48 @@
+package warnings
+
+import "sort"
+
+// A Stat is the number of warnings sharing the same key.
+type Stat struct {
+	Key   string // warning code, or message for warnings without a code
+	Count int
+}
+
+// statKey returns the key under which err is counted by Stats.
+func statKey(err error) string {
+	if w, ok := AsWarning(err); ok && w.Code != "" {
+		return w.Code
+	}
+	return err.Error()
+}
+
+// Stats returns the number of warnings in l per code, taking Warning.Count
+// into account. Warnings without a code are counted by their message. The
+// fatal error is not included.
+func (l List) Stats() map[string]int {
+	m := make(map[string]int)
+	for _, err := range l.Warnings {
+		m[statKey(err)] += countOf(err)
+	}
+	return m
+}
+
+// TopN returns the n most frequent warning codes (or messages) in l, most
+// frequent first. Entries with equal counts are ordered by key. If n < 0,
+// all entries are returned.
+func (l List) TopN(n int) []Stat {
+	var stats []Stat
+	for k, c := range l.Stats() {
+		stats = append(stats, Stat{k, c})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	if n >= 0 && n < len(stats) {
+		stats = stats[:n]
+	}
+	return stats
+}
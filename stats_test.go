@@ -0,0 +1,48 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var statsList = w.List{
+	Warnings: []error{
+		&w.Warning{Code: "C1", Msg: "a"},
+		&w.Warning{Code: "C2", Msg: "b"},
+		&w.Warning{Code: "C1", Msg: "c"},
+		warning("1w"),
+		&w.Warning{Code: "C2", Msg: "d", Count: 2},
+		&w.Warning{Code: "C1", Msg: "e"},
+	},
+	Fatal: &w.Warning{Code: "C1", Msg: "f"},
+}
+
+func TestStats(t *testing.T) {
+	want := map[string]int{"C1": 3, "C2": 3, "1w": 1}
+	if got := statsList.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats() = %v; want %v", got, want)
+	}
+}
+
+var topNTests = [...]struct {
+	n    int
+	want []w.Stat
+}{
+	{0, []w.Stat{}},
+	{1, []w.Stat{{"C1", 3}}},
+	{2, []w.Stat{{"C1", 3}, {"C2", 3}}},
+	{5, []w.Stat{{"C1", 3}, {"C2", 3}, {"1w", 1}}},
+	{-1, []w.Stat{{"C1", 3}, {"C2", 3}, {"1w", 1}}},
+}
+
+func TestTopN(t *testing.T) {
+	for _, tt := range topNTests {
+		got := statsList.TopN(tt.n)
+		if len(got) != len(tt.want) ||
+			len(got) > 0 && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TopN(%d) = %v; want %v", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package warnings
+
+import "log/syslog"
+
+// SyslogPriority returns the syslog priority for err: LOG_CRIT if err is a
+// fatal error, and otherwise LOG_INFO, LOG_WARNING or LOG_ERR depending on
+// its severity (see SeverityOf).
+func SyslogPriority(err error, fatal bool) syslog.Priority {
+	if fatal {
+		return syslog.LOG_CRIT
+	}
+	switch s := SeverityOf(err); {
+	case s <= Info:
+		return syslog.LOG_INFO
+	case s == Warn:
+		return syslog.LOG_WARNING
+	}
+	return syslog.LOG_ERR
+}
+
+// A SyslogWriter writes messages to syslog; it is implemented by
+// *syslog.Writer.
+type SyslogWriter interface {
+	Crit(m string) error
+	Err(m string) error
+	Warning(m string) error
+	Info(m string) error
+}
+
+// WriteSyslog writes each warning in l, followed by the fatal error (if any),
+// to w with the priority given by SyslogPriority. It returns the first error
+// encountered, if any.
+func (l List) WriteSyslog(w SyslogWriter) error {
+	var first error
+	write := func(err error, fatal bool) {
+		var werr error
+		switch m := err.Error(); SyslogPriority(err, fatal) {
+		case syslog.LOG_CRIT:
+			werr = w.Crit(m)
+		case syslog.LOG_ERR:
+			werr = w.Err(m)
+		case syslog.LOG_WARNING:
+			werr = w.Warning(m)
+		default:
+			werr = w.Info(m)
+		}
+		if first == nil {
+			first = werr
+		}
+	}
+	for _, err := range l.Warnings {
+		write(err, false)
+	}
+	for _, err := range l.FatalErrors() {
+		write(err, true)
+	}
+	return first
+}
@@ -0,0 +1,41 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package warnings_test
+
+import (
+	"log/syslog"
+	"reflect"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var _ w.SyslogWriter = &syslog.Writer{}
+
+type syslogRecorder []string
+
+func (r *syslogRecorder) add(p, m string) error  { *r = append(*r, p+" "+m); return nil }
+func (r *syslogRecorder) Crit(m string) error    { return r.add("crit", m) }
+func (r *syslogRecorder) Err(m string) error     { return r.add("err", m) }
+func (r *syslogRecorder) Warning(m string) error { return r.add("warning", m) }
+func (r *syslogRecorder) Info(m string) error    { return r.add("info", m) }
+
+func TestWriteSyslog(t *testing.T) {
+	l := w.List{
+		Warnings: []error{
+			warning("1w"),
+			&w.Warning{Severity: w.Info, Msg: "2i"},
+			&w.Warning{Severity: w.Error, Msg: "3e"},
+		},
+		Fatal: fatal("4f"),
+	}
+	var r syslogRecorder
+	if err := l.WriteSyslog(&r); err != nil {
+		t.Fatal(err)
+	}
+	want := syslogRecorder{"warning 1w", "info 2i", "err 3e", "crit 4f"}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("WriteSyslog() wrote %q; want %q", r, want)
+	}
+}
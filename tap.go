@@ -0,0 +1,32 @@
+package warnings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tapEscaper escapes descriptions of TAP test points.
+var tapEscaper = strings.NewReplacer("\n", " ", "#", `\#`)
+
+// WriteTAP writes l to w in TAP (Test Anything Protocol) format, with one
+// test point per error: each warning is a passing test point with a SKIP
+// directive naming its severity, and each fatal error is a failing test
+// point.
+func (l List) WriteTAP(w io.Writer) error {
+	b := bytes.NewBuffer(nil)
+	fatals := l.FatalErrors()
+	n := len(l.Warnings) + len(fatals)
+	fmt.Fprintf(b, "TAP version 13\n1..%d\n", n)
+	for i, err := range l.Warnings {
+		fmt.Fprintf(b, "ok %d - %s # SKIP %s\n", i+1,
+			tapEscaper.Replace(err.Error()), SeverityOf(err))
+	}
+	for i, err := range fatals {
+		fmt.Fprintf(b, "not ok %d - %s\n", len(l.Warnings)+i+1,
+			tapEscaper.Replace(err.Error()))
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
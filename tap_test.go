@@ -0,0 +1,40 @@
+package warnings_test
+
+import (
+	"bytes"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var tapTests = []struct {
+	l    w.List
+	want string
+}{
+	{w.List{}, "TAP version 13\n1..0\n"},
+	{
+		w.List{
+			Warnings: []error{
+				warning("1w #1"),
+				&w.Warning{Severity: w.Info, Msg: "2i\nmore"},
+			},
+			Fatal: fatal("3f"),
+		},
+		"TAP version 13\n1..3\n" +
+			"ok 1 - 1w \\#1 # SKIP warning\n" +
+			"ok 2 - 2i more # SKIP info\n" +
+			"not ok 3 - 3f\n",
+	},
+}
+
+func TestWriteTAP(t *testing.T) {
+	for _, tt := range tapTests {
+		b := bytes.NewBuffer(nil)
+		if err := tt.l.WriteTAP(b); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("WriteTAP() = %q; want %q", b, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package warnings
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// TerminalWidth returns the width in columns of the terminal w writes to, for
+// use as Text.Width or Pager.Width: the value of the COLUMNS environment
+// variable if set, or else the width reported by the terminal, if w is one.
+// It returns 0 if the width is unknown, e.g. if output is redirected to a
+// file.
+func TerminalWidth(w io.Writer) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if f, ok := w.(*os.File); ok {
+		return terminalWidth(f)
+	}
+	return 0
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package warnings
+
+import "os"
+
+// terminalWidth returns 0, as the terminal width can't be queried on this
+// platform; TerminalWidth still honors COLUMNS.
+func terminalWidth(f *os.File) int { return 0 }
@@ -0,0 +1,24 @@
+package warnings_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	defer os.Setenv("COLUMNS", os.Getenv("COLUMNS"))
+	tests := []struct {
+		columns string
+		want    int
+	}{{"", 0}, {"120", 120}, {"x", 0}, {"-1", 0}}
+	for _, tt := range tests {
+		os.Setenv("COLUMNS", tt.columns)
+		if got := w.TerminalWidth(bytes.NewBuffer(nil)); got != tt.want {
+			t.Errorf("COLUMNS=%q: TerminalWidth() = %d; want %d", tt.columns,
+				got, tt.want)
+		}
+	}
+}
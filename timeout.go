@@ -0,0 +1,33 @@
+package warnings
+
+import "time"
+
+// WithTimeout makes collection end once d has passed (as measured by Now):
+// the first call to Collect after that calls Done, and from then on, Collect
+// drops errors instead of panicking, counting them in List.Omitted and
+// Metrics.Late. This suits best-effort collection, e.g. during a graceful
+// shutdown window.
+func (c *Collector) WithTimeout(d time.Duration) {
+	c.deadline = c.now().Add(d)
+}
+
+// timeout reports whether collection has ended because the time set by
+// WithTimeout has passed, ending it if needed, and counts err as late if
+// so.
+func (c *Collector) timeout(err error) bool {
+	switch {
+	case c.deadline.IsZero():
+		return false
+	case !c.timedOut:
+		if c.done || c.now().Before(c.deadline) {
+			return false
+		}
+		c.Done()
+		c.timedOut = true
+	}
+	if err != nil {
+		c.l.Omitted++
+		c.metrics.Late++
+	}
+	return true
+}
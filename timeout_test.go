@@ -0,0 +1,44 @@
+package warnings_test
+
+import (
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestWithTimeout(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := w.NewCollector(isFatal)
+	c.Now = func() time.Time { return now }
+	c.WithTimeout(time.Second)
+	c.Collect(warning("1w"))
+	now = now.Add(time.Second)
+	if err := c.Collect(warning("2w")); err != nil {
+		t.Errorf("Collect(late) = %v; want nil", err)
+	}
+	if err := c.Collect(fatal("3f")); err != nil {
+		t.Errorf("Collect(late fatal) = %v; want nil", err)
+	}
+	c.Collect(nil)
+	l, ok := c.Done().(w.List)
+	if !ok || len(l.Warnings) != 1 || l.Omitted != 2 || l.Fatal != nil {
+		t.Errorf("Done() = %#v; want 1 warning, 2 omitted", l)
+	}
+	if m := c.Metrics(); m.Late != 2 {
+		t.Errorf("Metrics().Late = %d; want 2", m.Late)
+	}
+}
+
+func TestWithTimeoutDone(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.WithTimeout(time.Hour)
+	c.Collect(warning("1w"))
+	c.Done()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Collect after Done before the timeout didn't panic")
+		}
+	}()
+	c.Collect(warning("2w"))
+}
@@ -0,0 +1,51 @@
+package warnings
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// tracing is the runtime/trace state of a Collector, see WithTrace.
+type tracing struct {
+	ctx  context.Context // carrying task
+	task *trace.Task
+}
+
+// WithTrace instruments c for the execution tracer (see runtime/trace): it
+// starts a task "warnings.Collector" within ctx, which ends with Done; calls
+// to Collect and Done are traced as regions, and each collected error is
+// logged in the category "warning" or "fatal". This shows collection in
+// go tool trace, e.g. when debugging the latency of large validation runs.
+func (c *Collector) WithTrace(ctx context.Context) {
+	c.endTrace()
+	c.trace.ctx, c.trace.task = trace.NewTask(ctx, "warnings.Collector")
+}
+
+// traceRegion starts a region named name if c is traced, and returns a
+// function ending it.
+func (c *Collector) traceRegion(name string) (end func()) {
+	if c.trace.task == nil {
+		return func() {}
+	}
+	return trace.StartRegion(c.trace.ctx, name).End
+}
+
+// traceLog logs a collected error if c is traced.
+func (c *Collector) traceLog(err error, fatal bool) {
+	if c.trace.task == nil || !trace.IsEnabled() {
+		return
+	}
+	category := "warning"
+	if fatal {
+		category = "fatal"
+	}
+	trace.Log(c.trace.ctx, category, err.Error())
+}
+
+// endTrace ends the task started by WithTrace, if any.
+func (c *Collector) endTrace() {
+	if c.trace.task != nil {
+		c.trace.task.End()
+		c.trace = tracing{}
+	}
+}
@@ -0,0 +1,35 @@
+package warnings_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/trace"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestWithTrace(t *testing.T) {
+	collect := func() error {
+		c := w.NewCollector(isFatal)
+		c.WithTrace(context.Background())
+		c.Collect(warning("1w"))
+		c.Collect(fatal("2f"))
+		return c.Done()
+	}
+	if err := collect(); err == nil {
+		t.Errorf("Done() = nil; want an error")
+	}
+	b := bytes.NewBuffer(nil)
+	if err := trace.Start(b); err != nil {
+		t.Skipf("can't start tracing: %v", err)
+	}
+	err := collect()
+	trace.Stop()
+	if err == nil {
+		t.Errorf("Done() = nil; want an error")
+	}
+	if !bytes.Contains(b.Bytes(), []byte("warnings.Collect")) {
+		t.Errorf("trace doesn't contain the region warnings.Collect")
+	}
+}
@@ -0,0 +1,51 @@
+package warnings
+
+import "time"
+
+// List returns the errors collected so far, excluding warnings that have
+// expired (see TTL). Unlike Done, it doesn't end collection.
+func (c *Collector) List() List {
+	c.expire()
+	l := c.l
+	l.Warnings = append([]error(nil), c.l.Warnings...)
+	return l
+}
+
+// stamp records the collection time of the warnings added last, if TTL is
+// set.
+func (c *Collector) stamp() {
+	if c.TTL <= 0 {
+		return
+	}
+	now := c.now()
+	for len(c.times) < len(c.l.Warnings) {
+		c.times = append(c.times, now)
+	}
+}
+
+// expire drops the warnings collected more than TTL ago.
+func (c *Collector) expire() {
+	if c.TTL <= 0 || len(c.times) == 0 {
+		return
+	}
+	cutoff := c.now().Add(-c.TTL)
+	n := 0
+	for n < len(c.times) && !c.times[n].After(cutoff) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	for _, err := range c.l.Warnings[:n] {
+		c.l.Expired += countOf(err)
+	}
+	c.l.Warnings = append([]error(nil), c.l.Warnings[n:]...)
+	c.times = append([]time.Time(nil), c.times[n:]...)
+	for code, e := range c.once {
+		if e.i < n {
+			delete(c.once, code)
+		} else {
+			e.i -= n
+		}
+	}
+}
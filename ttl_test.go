@@ -0,0 +1,37 @@
+package warnings_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := w.NewCollector(isFatalStructured)
+	c.TTL = time.Minute
+	c.OncePerCode = true
+	c.Now = func() time.Time { return now }
+	c.Collect(warning("1w"))
+	c.Collect(&w.Warning{Code: "C1", Msg: "a"})
+	now = now.Add(30 * time.Second)
+	c.Collect(&w.Warning{Code: "C1", Msg: "b"})
+	c.Collect(warning("2w"))
+	now = now.Add(30 * time.Second)
+	l := c.List()
+	want := []error{warning("2w")}
+	if !reflect.DeepEqual(l.Warnings, want) || l.Expired != 3 {
+		t.Errorf("List() = %v (%d expired); want %v (3 expired)", l.Warnings,
+			l.Expired, want)
+	}
+	c.Collect(&w.Warning{Code: "C1", Msg: "c"})
+	c.Collect(&w.Warning{Code: "C1", Msg: "d"})
+	now = now.Add(45 * time.Second)
+	l = c.Done().(w.List)
+	wantErr := "warnings:\n(4 earlier warning(s) expired)\n[C1] c (1 more times)\n"
+	if l.Error() != wantErr {
+		t.Errorf("Done() = %q; want %q", l.Error(), wantErr)
+	}
+}
@@ -0,0 +1,78 @@
+package warnings
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VersionGate returns an IsFatal function for a product at the given version,
+// ratcheting strictness across releases: fatalFrom maps warning codes to the
+// version from which warnings with that code are fatal, e.g.
+//
+//	isFatal = warnings.VersionGate(isFatal, version, map[string]string{
+//		"CFG012": "v2.0", // a warning in v1.x, fatal from v2
+//	})
+//
+// Errors whose code isn't in fatalFrom are classified by isFatal (if nil, by
+// severity, as for Collector.IsFatal).
+func VersionGate(isFatal func(error) bool, version string, fatalFrom map[string]string) func(error) bool {
+	isFatal = orSeverity(isFatal)
+	return func(err error) bool {
+		if from, ok := fatalFrom[codeOf(err)]; ok && compareVersions(version, from) >= 0 {
+			return true
+		}
+		return isFatal(err)
+	}
+}
+
+// compareVersions compares versions such as "1.2", "v2.0.1" or "2.0-rc1",
+// returning -1, 0 or +1. Dot-separated parts are compared numerically where
+// possible, missing parts count as 0, and a pre-release (after "-") precedes
+// the release.
+func compareVersions(a, b string) int {
+	a, b = strings.TrimPrefix(a, "v"), strings.TrimPrefix(b, "v")
+	var apre, bpre string
+	if i := strings.Index(a, "-"); i >= 0 {
+		a, apre = a[:i], a[i+1:]
+	}
+	if i := strings.Index(b, "-"); i >= 0 {
+		b, bpre = b[:i], b[i+1:]
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		ap, bp := "0", "0"
+		if i < len(as) {
+			ap = as[i]
+		}
+		if i < len(bs) {
+			bp = bs[i]
+		}
+		if c := comparePart(ap, bp); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case apre == bpre:
+		return 0
+	case apre == "":
+		return +1
+	case bpre == "":
+		return -1
+	}
+	return comparePart(apre, bpre)
+}
+
+func comparePart(a, b string) int {
+	an, aerr := strconv.Atoi(a)
+	bn, berr := strconv.Atoi(b)
+	if aerr != nil || berr != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case an < bn:
+		return -1
+	case an > bn:
+		return +1
+	}
+	return 0
+}
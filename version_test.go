@@ -0,0 +1,35 @@
+package warnings_test
+
+import (
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var versionGateTests = []struct {
+	version string
+	code    string
+	want    bool
+}{
+	{"v1.9", "CFG012", false},
+	{"v2.0.0-beta", "CFG012", false},
+	{"v2.0.0", "CFG012", true},
+	{"2.1", "CFG012", true},
+	{"v1.10", "CFG013", true},
+	{"v1.9.9", "CFG013", false},
+	{"v9", "CFG014", false},
+}
+
+func TestVersionGate(t *testing.T) {
+	table := map[string]string{"CFG012": "v2", "CFG013": "v1.10"}
+	for _, tt := range versionGateTests {
+		isFatal := w.VersionGate(isFatalStructured, tt.version, table)
+		if got := isFatal(&w.Warning{Code: tt.code}); got != tt.want {
+			t.Errorf("VersionGate(%q)(%s) = %v; want %v", tt.version, tt.code,
+				got, tt.want)
+		}
+	}
+	if !w.VersionGate(isFatal, "v1", nil)(fatal("1f")) {
+		t.Errorf("VersionGate() doesn't defer to isFatal")
+	}
+}
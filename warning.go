@@ -0,0 +1,305 @@
+package warnings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Position describes a location in an input (such as a config file).
+// A Position is valid if the line number is > 0.
+type Position struct {
+	Filename string // filename, if any
+	Line     int    // line number, starting at 1
+	Column   int    // column number, starting at 1 (byte count)
+}
+
+// IsValid reports whether the position is valid.
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+// String returns a string in one of several forms:
+//
+//	file:line:column    valid position with file name
+//	file:line           valid position with file name but no column (column == 0)
+//	line:column         valid position without file name
+//	line                valid position without file name and no column (column == 0)
+//	file                invalid position with file name
+//	-                   invalid position without file name
+func (p Position) String() string {
+	s := p.Filename
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += strconv.Itoa(p.Line)
+		if p.Column != 0 {
+			s += ":" + strconv.Itoa(p.Column)
+		}
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
+}
+
+// Severity is the severity of a Warning. The zero value is Warn. Severities
+// are ordered by their value; besides the predefined ones, custom severities
+// can be registered using RegisterSeverity.
+type Severity int
+
+// Severities, in increasing order. The values leave room for custom
+// severities in between (see RegisterSeverity).
+const (
+	Info  Severity = -10 // informational message
+	Warn  Severity = 0   // warning
+	Error Severity = 10  // error
+)
+
+// A SeverityLevel describes a Severity.
+type SeverityLevel struct {
+	Name string // name, e.g. "blocker"
+	// Color is the ANSI escape sequence used for the severity in colored
+	// output, e.g. "\x1b[35m"; optional.
+	Color string
+	// ExitCode is the exit status for warnings of the severity, if not
+	// mapped otherwise by an ExitPolicy; optional.
+	ExitCode int
+}
+
+var severityLevels = map[Severity]SeverityLevel{
+	Info:  {Name: "info"},
+	Warn:  {Name: "warning", Color: "\x1b[33m"},
+	Error: {Name: "error", Color: "\x1b[31m"},
+}
+
+// RegisterSeverity registers a custom severity s described by level, or
+// redefines an existing one, e.g. for a taxonomy with levels like
+// "advisory" (between Info and Warn) and "blocker" (above Error):
+//
+//	const (
+//		Advisory warnings.Severity = -5
+//		Blocker  warnings.Severity = 20
+//	)
+//
+//	func init() {
+//		warnings.RegisterSeverity(Advisory, warnings.SeverityLevel{
+//			Name: "advisory",
+//		})
+//		warnings.RegisterSeverity(Blocker, warnings.SeverityLevel{
+//			Name: "blocker", Color: "\x1b[35m", ExitCode: 3,
+//		})
+//	}
+//
+// The name is used by String and accepted by UnmarshalText (e.g. in JSON).
+// RegisterSeverity isn't safe for concurrent use; call it during
+// initialization.
+func RegisterSeverity(s Severity, level SeverityLevel) {
+	severityLevels[s] = level
+}
+
+// Level returns the description of s; for an unregistered severity, only
+// the Name is set, as returned by String.
+func (s Severity) Level() SeverityLevel {
+	if level, ok := severityLevels[s]; ok {
+		return level
+	}
+	return SeverityLevel{Name: "severity(" + strconv.Itoa(int(s)) + ")"}
+}
+
+// String returns the name of s ("info", "warning", "error", or that of a
+// registered severity).
+func (s Severity) String() string {
+	return s.Level().Name
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for sev, level := range severityLevels {
+		if level.Name == string(text) {
+			*s = sev
+			return nil
+		}
+	}
+	return fmt.Errorf("warnings: unknown severity %q", text)
+}
+
+// A Warning is a structured error carrying an optional code and position in
+// addition to its message. A Warning may be collected as a warning or as a
+// fatal error; which one it is, is up to the IsFatal function of the
+// Collector.
+type Warning struct {
+	Code     string   // stable identifier such as "CFG001"; optional
+	Severity Severity // severity; defaults to Warn
+	Msg      string   // message
+	Pos      Position // position in the input; optional
+	Hint     string   // how to resolve the warning, or a URL; optional
+	Err      error    // underlying cause; optional
+	// Count is the number of occurrences the Warning stands for, if other
+	// warnings have been merged into it (e.g. by Collector.OncePerCode); 0
+	// means 1.
+	Count int
+	// Attrs holds additional key/value attributes, e.g. for machine
+	// readable context in JSON and slog output.
+	Attrs []Attr
+	// Priority orders warnings by how actionable they are, independently
+	// of Severity; higher priorities are rendered first if Text.ByPriority
+	// is set. The default is 0.
+	Priority int
+}
+
+// Error implements the error interface.
+func (w *Warning) Error() string {
+	if w.Pos.Filename == "" && !w.Pos.IsValid() {
+		return w.message()
+	}
+	return w.Pos.String() + ": " + w.message()
+}
+
+// Unwrap returns the underlying cause, if any.
+func (w *Warning) Unwrap() error { return w.Err }
+
+// message returns the message of w, including the cause but not the
+// position.
+func (w *Warning) message() string {
+	switch {
+	case w.Err == nil:
+		return w.Msg
+	case w.Msg == "":
+		return w.Err.Error()
+	}
+	return w.Msg + ": " + w.Err.Error()
+}
+
+// copyWarning returns a copy of err if it is a *Warning, and otherwise a new
+// *Warning wrapping err, with the same message, code and severity.
+func copyWarning(err error) *Warning {
+	if w, ok := err.(*Warning); ok {
+		nw := *w
+		return &nw
+	}
+	return &Warning{Code: codeOf(err), Severity: SeverityOf(err), Err: err}
+}
+
+// countOf returns the number of occurrences err stands for (see
+// Warning.Count).
+func countOf(err error) int {
+	if w, ok := AsWarning(err); ok && w.Count > 1 {
+		return w.Count
+	}
+	return 1
+}
+
+// messageOf returns the message of err, excluding the position if err is a
+// *Warning.
+func messageOf(err error) string {
+	if w, ok := AsWarning(err); ok {
+		return w.message()
+	}
+	return err.Error()
+}
+
+// codeOf returns the code of the first *Warning in the chain of err, or "" if
+// there is none.
+func codeOf(err error) string {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w.Code
+	}
+	return ""
+}
+
+// PriorityOf returns the priority of the first *Warning in the chain of err,
+// or 0 if there is none.
+func PriorityOf(err error) int {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w.Priority
+	}
+	return 0
+}
+
+// A SeverityError is an error reporting its own severity, allowing
+// libraries to embed severity in their error types (see SeverityOf).
+type SeverityError interface {
+	error
+	Severity() Severity
+}
+
+// SeverityOf returns the severity of the first *Warning in the chain of err,
+// or otherwise of the first SeverityError, or Warn if there is neither.
+func SeverityOf(err error) Severity {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w.Severity
+	}
+	var se SeverityError
+	if errors.As(err, &se) {
+		return se.Severity()
+	}
+	return Warn
+}
+
+// FatalFrom returns a function for use as Collector.IsFatal classifying errors
+// with severity s or higher (see SeverityOf) as fatal.
+func FatalFrom(s Severity) func(error) bool {
+	return func(err error) bool { return SeverityOf(err) >= s }
+}
+
+// orSeverity returns isFatal, or if it is nil, a function classifying errors
+// with severity Error or higher as fatal, as Collector does by default.
+func orSeverity(isFatal func(error) bool) func(error) bool {
+	if isFatal == nil {
+		return FatalFrom(Error)
+	}
+	return isFatal
+}
+
+// Fingerprint returns a stable hash of err, suitable for recognizing the same
+// warning across runs (e.g. for deduplication, baselines or grouping of
+// alerts). For a *Warning, the hash covers its code, message and position;
+// for other errors, it covers the error message.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	var code, msg string
+	var pos Position
+	if w, ok := AsWarning(err); ok {
+		code, msg, pos = w.Code, w.message(), w.Pos
+	} else {
+		msg = err.Error()
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%q %q %q %d %d", code, msg, pos.Filename, pos.Line,
+		pos.Column)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// Fingerprint returns a stable hash of the fatal error and the warnings in l.
+// The order in which the warnings were collected does not affect the result.
+func (l List) Fingerprint() string {
+	fps := make([]string, 0, len(l.Warnings))
+	for _, err := range l.Warnings {
+		fps = append(fps, Fingerprint(err))
+	}
+	sort.Strings(fps)
+	h := sha256.New()
+	fmt.Fprintf(h, "fatal %s\n", Fingerprint(l.Fatal))
+	for i, err := range l.Fatals {
+		if i > 0 {
+			fmt.Fprintf(h, "fatal %s\n", Fingerprint(err))
+		}
+	}
+	for _, fp := range fps {
+		fmt.Fprintf(h, "warning %s\n", fp)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
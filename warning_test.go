@@ -0,0 +1,145 @@
+package warnings_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+var _ error = &w.Warning{}
+
+// isFatalStructured is like isFatal, but also treats *Warning as a warning.
+func isFatalStructured(err error) bool {
+	_, ok := err.(*w.Warning)
+	return !ok && isFatal(err)
+}
+
+var warningErrorTests = [...]struct {
+	warning *w.Warning
+	want    string
+}{
+	{&w.Warning{Msg: "msg"}, "msg"},
+	{&w.Warning{Code: "C1", Msg: "msg"}, "msg"},
+	{&w.Warning{Msg: "msg", Err: errors.New("cause")}, "msg: cause"},
+	{&w.Warning{Err: errors.New("cause")}, "cause"},
+	{&w.Warning{Msg: "msg", Pos: w.Position{Filename: "f", Line: 1, Column: 2}}, "f:1:2: msg"},
+	{&w.Warning{Msg: "msg", Pos: w.Position{Line: 3}}, "3: msg"},
+	{&w.Warning{Msg: "msg", Pos: w.Position{Filename: "f"}}, "f: msg"},
+}
+
+func TestWarningError(t *testing.T) {
+	for _, tt := range warningErrorTests {
+		if got := tt.warning.Error(); got != tt.want {
+			t.Errorf("%#v.Error() = %q; want %q", tt.warning, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := &w.Warning{Code: "C1", Msg: "msg", Pos: w.Position{Filename: "f", Line: 1}}
+	b := &w.Warning{Code: "C1", Msg: "msg", Pos: w.Position{Filename: "f", Line: 1}}
+	if w.Fingerprint(a) != w.Fingerprint(b) {
+		t.Errorf("Fingerprint differs for equal warnings")
+	}
+	for _, c := range []*w.Warning{
+		{Code: "C2", Msg: "msg", Pos: w.Position{Filename: "f", Line: 1}},
+		{Code: "C1", Msg: "other", Pos: w.Position{Filename: "f", Line: 1}},
+		{Code: "C1", Msg: "msg", Pos: w.Position{Filename: "f", Line: 2}},
+	} {
+		if w.Fingerprint(a) == w.Fingerprint(c) {
+			t.Errorf("Fingerprint(%v) == Fingerprint(%v)", a, c)
+		}
+	}
+	if w.Fingerprint(warning("1w")) != w.Fingerprint(&w.Warning{Msg: "1w"}) {
+		t.Errorf("Fingerprint differs for equal messages")
+	}
+	if w.Fingerprint(nil) != "" {
+		t.Errorf("Fingerprint(nil) = %q; want empty", w.Fingerprint(nil))
+	}
+}
+
+func TestListFingerprint(t *testing.T) {
+	l1 := w.List{Warnings: []error{warning("1w"), warning("2w")}}
+	l2 := w.List{Warnings: []error{warning("2w"), warning("1w")}}
+	if l1.Fingerprint() != l2.Fingerprint() {
+		t.Errorf("List.Fingerprint depends on warning order")
+	}
+	l3 := w.List{Warnings: l1.Warnings, Fatal: fatal("3f")}
+	if l1.Fingerprint() == l3.Fingerprint() {
+		t.Errorf("List.Fingerprint ignores fatal error")
+	}
+}
+
+// leveled is an error reporting its own severity.
+type leveled struct {
+	msg string
+	sev w.Severity
+}
+
+func (e leveled) Error() string        { return e.msg }
+func (e leveled) Severity() w.Severity { return e.sev }
+
+func TestSeverityOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want w.Severity
+	}{
+		{warning("w"), w.Warn},
+		{&w.Warning{Severity: w.Info}, w.Info},
+		{leveled{"e", w.Error}, w.Error},
+		{fmt.Errorf("ctx: %w", leveled{"i", w.Info}), w.Info},
+		{&w.Warning{Severity: w.Info, Err: leveled{"e", w.Error}}, w.Info},
+		{w.AddAttrs(leveled{"e", w.Error}, w.Field("k", 1)), w.Error},
+	}
+	for _, tt := range tests {
+		if got := w.SeverityOf(tt.err); got != tt.want {
+			t.Errorf("SeverityOf(%v) = %v; want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCollectorDefaultIsFatal(t *testing.T) {
+	c := &w.Collector{}
+	if err := c.Collect(leveled{"1w", w.Warn}); err != nil {
+		t.Errorf("Collect(warning) = %v; want nil", err)
+	}
+	if err := c.Collect(&w.Warning{Msg: "2i", Severity: w.Info}); err != nil {
+		t.Errorf("Collect(info) = %v; want nil", err)
+	}
+	if err := c.Collect(leveled{"3e", w.Error}); err == nil {
+		t.Errorf("Collect(error) = nil; want an error")
+	}
+	isFatal := w.FatalFrom(w.Warn)
+	if !isFatal(leveled{"w", w.Warn}) || isFatal(&w.Warning{Severity: w.Info}) {
+		t.Errorf("FatalFrom(Warn) misclassifies")
+	}
+}
+
+func TestRegisterSeverity(t *testing.T) {
+	const advisory, blocker w.Severity = -5, 20
+	w.RegisterSeverity(advisory, w.SeverityLevel{Name: "advisory"})
+	w.RegisterSeverity(blocker, w.SeverityLevel{Name: "blocker", ExitCode: 3})
+	if !(w.Info < advisory && advisory < w.Warn) {
+		t.Errorf("advisory isn't ordered between Info and Warn")
+	}
+	if got := blocker.String(); got != "blocker" {
+		t.Errorf("String() = %q; want blocker", got)
+	}
+	var s w.Severity
+	if err := s.UnmarshalText([]byte("blocker")); err != nil || s != blocker {
+		t.Errorf("UnmarshalText(blocker) = %v, %v; want %v", s, err, blocker)
+	}
+	if got := w.Severity(21).String(); got != "severity(21)" {
+		t.Errorf("String() = %q; want severity(21)", got)
+	}
+	if !w.FatalFrom(w.Error)(&w.Warning{Severity: blocker}) {
+		t.Errorf("blocker isn't ordered above Error")
+	}
+	p := w.ExitPolicy{Severities: map[w.Severity]int{w.Error: 2}}
+	l := w.List{Warnings: []error{&w.Warning{Severity: blocker}, &w.Warning{Severity: w.Error}}}
+	if got := p.ExitCode(l); got != 3 {
+		t.Errorf("ExitCode() = %d; want 3", got)
+	}
+}
@@ -73,8 +73,6 @@
 //
 // TODO
 //
-//  - optionally limit the number of warnings (e.g. stop after 20 warnings) (?)
-//  - consider interaction with contexts
 //  - go vet-style invocations verifier
 //  - semi-automatic code converter
 //
@@ -82,23 +80,54 @@ package warnings // import "gopkg.in/warnings.v0"
 
 import (
 	"bytes"
+	"errors"
+	"expvar"
 	"fmt"
+	"io"
+	"time"
 )
 
-// List holds a collection of warnings and optionally one fatal error.
+// List holds a collection of warnings and optionally one fatal error. Lists
+// can be built using NewList, AddWarning and SetFatal rather than by setting
+// the fields directly.
 type List struct {
 	Warnings []error
 	Fatal    error
+	// Fatals holds all fatal errors, starting with Fatal, if there is more
+	// than one (e.g. in Lists merged from several sources); see AddFatal and
+	// FatalErrors.
+	Fatals []error
+	// Drained is the number of warnings that have been written out and
+	// released by Collector.DrainTo, and are thus not included in Warnings.
+	Drained int
+	// Omitted is the number of warnings dropped because the limit set by
+	// Collector.MaxWarnings was reached, after the time set by
+	// Collector.WithTimeout, or by List.Filter.
+	Omitted int
+	// Expired is the number of warnings that were dropped after
+	// Collector.TTL had passed, and are thus not included in Warnings.
+	Expired int
+	// Total is the number of records (or other units of input) the warnings
+	// relate to, as set by Collector.SetTotal, for rate based policies such
+	// as FatalIfWarningRateAbove; 0 if unknown.
+	Total int
 }
 
 // Error implements the error interface.
 func (l List) Error() string {
+	return l.text(Text{})
+}
+
+// text returns the errors in l as returned by Error, with the options of t.
+func (l List) text(t Text) string {
 	b := bytes.NewBuffer(nil)
 	if l.Fatal != nil {
 		fmt.Fprintln(b, "fatal:")
-		fmt.Fprintln(b, l.Fatal)
+		for _, err := range l.FatalErrors() {
+			t.writeItem(b, "", err)
+		}
 	}
-	switch len(l.Warnings) {
+	switch l.count() {
 	case 0:
 	// nop
 	case 1:
@@ -106,24 +135,158 @@ func (l List) Error() string {
 	default:
 		fmt.Fprintln(b, "warnings:")
 	}
+	if l.Drained > 0 {
+		fmt.Fprintf(b, "(%d earlier warning(s) already written)\n", l.Drained)
+	}
+	if l.Expired > 0 {
+		fmt.Fprintf(b, "(%d earlier warning(s) expired)\n", l.Expired)
+	}
 	for _, err := range l.Warnings {
-		fmt.Fprintln(b, err)
+		t.writeItem(b, "", err)
+	}
+	if l.Omitted > 0 {
+		fmt.Fprintf(b, "(%d more warning(s) omitted)\n", l.Omitted)
 	}
 	return b.String()
 }
 
+// count returns the number of warnings collected into l, including those
+// drained, omitted or expired.
+func (l List) count() int {
+	return len(l.Warnings) + l.Drained + l.Omitted + l.Expired
+}
+
+// NewList returns a List holding copies of warnings (without nil entries)
+// and fatal.
+func NewList(warnings []error, fatal error) List {
+	var l List
+	for _, err := range warnings {
+		l.AddWarning(err)
+	}
+	l.SetFatal(fatal)
+	return l
+}
+
+// AddWarning appends err to the warnings in l, unless it is nil.
+func (l *List) AddWarning(err error) {
+	if err != nil {
+		l.Warnings = append(l.Warnings, err)
+	}
+}
+
+// SetFatal sets the fatal error of l, replacing any others.
+func (l *List) SetFatal(err error) { l.Fatal, l.Fatals = err, nil }
+
+// AddFatal adds err to the fatal errors in l, unless it is nil: it becomes
+// Fatal if l holds none yet, and is added to Fatals otherwise.
+func (l *List) AddFatal(err error) {
+	switch {
+	case err == nil:
+	case l.Fatal == nil:
+		l.Fatal = err
+	default:
+		if len(l.Fatals) == 0 {
+			l.Fatals = []error{l.Fatal}
+		}
+		l.Fatals = append(l.Fatals, err)
+	}
+}
+
+// FatalErrors returns all fatal errors in l: Fatals if set, and otherwise
+// Fatal, if any.
+func (l List) FatalErrors() []error {
+	switch {
+	case len(l.Fatals) > 0:
+		return l.Fatals
+	case l.Fatal != nil:
+		return []error{l.Fatal}
+	}
+	return nil
+}
+
+// Len returns the number of warnings in l.Warnings.
+func (l List) Len() int { return len(l.Warnings) }
+
+// WarningAt returns the i-th warning in l.Warnings.
+func (l List) WarningAt(i int) error { return l.Warnings[i] }
+
 // A Collector collects errors up to the first fatal error.
 type Collector struct {
-	// IsFatal distinguishes between warnings and fatal errors.
+	// IsFatal distinguishes between warnings and fatal errors. If nil, errors
+	// with severity Error or higher are fatal (see SeverityOf and FatalFrom).
 	IsFatal func(error) bool
+	// FatalFunc, if set, is used instead of IsFatal, additionally receiving
+	// the state of the collection, for policies such as "fatal only during
+	// the apply phase" or "fatal after 5 minutes".
+	FatalFunc func(ctx CollectContext, err error) bool
 	// FatalWithWarnings set to true means that a fatal error is returned as
 	// a List together with all warnings so far. The default behavior is to
-	// only return the fatal error and discard any warnings that have been
-	// collected.
+	// only return the fatal error; if any warnings have been collected, it is
+	// wrapped in a *FatalError so that they can still be recovered using
+	// errors.As.
 	FatalWithWarnings bool
+	// Ignore, if set, reports whether a warning is to be dropped instead of
+	// collected. It is not called for fatal errors.
+	Ignore func(error) bool
+	// MaxWarnings, if > 0, limits the number of warnings kept; any further
+	// warnings are dropped and only counted in List.Omitted.
+	MaxWarnings int
+	// OncePerCode set to true means that only the first warning with each
+	// code is kept; further warnings with the same code only increment its
+	// Count. Warnings without a code are all kept.
+	OncePerCode bool
+	// Hook, if set, is called synchronously for each collected error.
+	Hook func(e Event)
+	// Reporter, if set, receives the collected errors asynchronously.
+	Reporter Reporter
+	// Counters, if set, counts the collected warnings per code; warnings
+	// without a code are counted under "-". Publishing it with expvar (e.g.
+	// using expvar.NewMap("warnings")) exposes the counters on /debug/vars.
+	Counters *expvar.Map
+	// DedupWindow, if > 0, drops warnings identical (see Fingerprint) to one
+	// kept less than DedupWindow ago, so that long-lived collectors still
+	// see recurrences.
+	DedupWindow time.Duration
+	// TTL, if > 0, is the time after which warnings drop out of the
+	// collected List; they are then only counted in List.Expired. It must be
+	// set before collecting.
+	TTL time.Duration
+	// Now, if set, is used instead of time.Now, e.g. for testing.
+	Now func() time.Time
+	// Finalize, if set, is called by Done with the collected List unless
+	// collection has ended on a fatal error; a non-nil result is collected as
+	// the fatal error, e.g. for ratio-based quality gates (see
+	// MaxWarningRatio).
+	Finalize FinalizeFunc
+
+	l       List
+	done    bool
+	phases  []Phase
+	events  chan Event
+	reports *reporting
+	once    map[string]*onceEntry // per code, for OncePerCode
 
-	l    List
-	done bool
+	escalations map[string]*escalation // per code, see Escalate
+	seen        map[string]time.Time   // per fingerprint, for DedupWindow
+	seenPrune   int                    // size of seen at which to prune it
+	times       []time.Time            // per warning, for TTL
+	cause       error                  // see WithCause
+	attrs       []Attr                 // see WithAttrs
+	middleware  []Middleware           // see Use
+	metrics     Metrics                // see Metrics
+	deadline    time.Time              // see WithTimeout
+	timedOut    bool                   // whether deadline has passed
+	trace       tracing                // see WithTrace
+	started     time.Time              // first call to Collect or Phase
+	key         string                 // see KeyedCollector
+}
+
+// Interface is the interface implemented by Collector. Functions may accept an
+// Interface rather than a *Collector to allow substituting other
+// implementations, e.g. a warningstest.RecordingCollector in tests.
+type Interface interface {
+	Collect(err error, attrs ...Attr) error
+	Done() error
 }
 
 // NewCollector returns a new Collector; it uses isFatal to distinguish between
@@ -132,40 +295,231 @@ func NewCollector(isFatal func(error) bool) *Collector {
 	return &Collector{IsFatal: isFatal}
 }
 
+// With creates a Collector using isFatal, calls fn with it, and returns the
+// errors collected, ensuring that Done is always called. If fn returns a
+// non-nil error, it is collected before calling Done, unless it is the error
+// returned by Collect (i.e. collection has already ended on a fatal error).
+func With(isFatal func(error) bool, fn func(c *Collector) error) error {
+	c := NewCollector(isFatal)
+	if err := fn(c); err != nil && !c.done {
+		c.Collect(err)
+	}
+	return c.Done()
+}
+
 // Collect collects a single error (warning or fatal). It returns nil if
 // collection can continue (only warnings so far), or otherwise the errors
 // collected. Collect mustn't be called after the first fatal error or after
 // Done has been called.
-func (c *Collector) Collect(err error) error {
+//
+// Any attrs are attached to the error as collected (see AddAttrs); the error
+// is classified by IsFatal before that. The error passes through the
+// middleware added with Use first.
+func (c *Collector) Collect(err error, attrs ...Attr) error {
+	defer c.traceRegion("warnings.Collect")()
+	if c.timeout(err) {
+		return nil
+	}
+	if c.done {
+		panic("warnings.Collector already done")
+	}
+	if err == nil {
+		return nil
+	}
+	if len(c.middleware) > 0 {
+		return c.chain(attrs)(err)
+	}
+	return c.collect(err, attrs)
+}
+
+// collect collects err, as passed through the middleware, with attrs.
+func (c *Collector) collect(err error, attrs []Attr) error {
 	if c.done {
 		panic("warnings.Collector already done")
 	}
 	if err == nil {
 		return nil
 	}
-	if c.IsFatal(err) {
+	c.start()
+	c.expire()
+	fatal := c.isFatal(err)
+	if len(c.attrs) > 0 {
+		attrs = append(c.attrs[:len(c.attrs):len(c.attrs)], attrs...)
+	}
+	err = AddAttrs(err, attrs...)
+	if c.cause != nil {
+		err = &CausedError{err, c.cause}
+	}
+	c.countMetrics(err)
+	if !fatal && c.Ignore != nil && c.Ignore(err) {
+		c.metrics.Suppressed++
+		return nil
+	}
+	if !fatal {
+		fatal = c.escalate(err)
+	}
+	if !fatal && c.DedupWindow > 0 && c.dedup(err) {
+		c.metrics.Deduped++
+		return nil
+	}
+	if !fatal && c.MaxWarnings > 0 &&
+		len(c.l.Warnings)+c.l.Drained >= c.MaxWarnings {
+		c.l.Omitted++
+		c.metrics.Truncated++
+		return nil
+	}
+	if fatal {
 		c.done = true
 		c.l.Fatal = err
 	} else {
-		c.l.Warnings = append(c.l.Warnings, err)
+		c.addWarning(err)
+		c.stamp()
+		c.countWarning(err)
 	}
+	c.phaseCollect(err, fatal)
+	c.emit(err, fatal)
+	c.report(err, fatal)
+	c.traceLog(err, fatal)
 	if c.l.Fatal != nil {
 		return c.erorr()
 	}
 	return nil
 }
 
+// isFatal reports whether err is a fatal error, according to FatalFunc or
+// IsFatal.
+func (c *Collector) isFatal(err error) bool {
+	if c.FatalFunc != nil {
+		return c.FatalFunc(c.context(), err)
+	}
+	if c.IsFatal == nil {
+		return SeverityOf(err) >= Error
+	}
+	return c.IsFatal(err)
+}
+
 // Done ends collection and returns the collected error(s).
 func (c *Collector) Done() error {
+	defer c.endTrace()
+	defer c.traceRegion("warnings.Done")()
+	if !c.done && !c.finalize() {
+		c.closeEvents()
+		c.closeReports()
+	}
 	c.done = true
+	c.endPhase()
+	c.expire()
 	return c.erorr()
 }
 
+// Finish ends collection like Done, without returning the collected errors;
+// use Report to render them, or Done to obtain them.
+func (c *Collector) Finish() { c.Done() }
+
+// Report renders the collected errors to w using r (Text{} if nil). It can
+// be called repeatedly with different Renderers, e.g. quiet for logs and
+// verbose for the user, but only after collection has ended (see Finish).
+func (c *Collector) Report(w io.Writer, r Renderer) error {
+	if !c.done {
+		panic("warnings.Collector not done")
+	}
+	if r == nil {
+		r = Text{}
+	}
+	return r.Render(w, c.List())
+}
+
+// Warnings returns a copy of the warnings collected so far (see List).
+func (c *Collector) Warnings() []error {
+	return c.List().Warnings
+}
+
+// Fatal returns the fatal error collected, if any.
+func (c *Collector) Fatal() error {
+	return c.l.Fatal
+}
+
+// now returns the current time, using c.Now if set.
+func (c *Collector) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// DrainTo writes the warnings collected so far to w, one per line, and
+// releases them, keeping memory use flat during long collections. Drained
+// warnings are no longer included in List.Warnings, but are accounted for in
+// List.Drained. If writing fails, the warnings are kept.
+func (c *Collector) DrainTo(w io.Writer) error {
+	b := bytes.NewBuffer(nil)
+	for _, err := range c.l.Warnings {
+		fmt.Fprintln(b, err)
+	}
+	if _, err := w.Write(b.Bytes()); err != nil {
+		return err
+	}
+	c.l.Drained += len(c.l.Warnings)
+	c.l.Warnings = nil
+	c.once = nil
+	c.times = nil
+	return nil
+}
+
+// addWarning adds err to the warnings collected, merging it with a previous
+// one if OncePerCode is set.
+func (c *Collector) addWarning(err error) {
+	code := codeOf(err)
+	if !c.OncePerCode || code == "" {
+		c.l.Warnings = append(c.l.Warnings, err)
+		return
+	}
+	e, ok := c.once[code]
+	if !ok {
+		if c.once == nil {
+			c.once = make(map[string]*onceEntry)
+		}
+		c.once[code] = &onceEntry{i: len(c.l.Warnings)}
+		c.l.Warnings = append(c.l.Warnings, err)
+		return
+	}
+	if e.w == nil {
+		// copy rather than modify the collected error
+		e.w = copyWarning(c.l.Warnings[e.i])
+		e.w.Count = countOf(e.w)
+		c.l.Warnings[e.i] = e.w
+	}
+	e.w.Count += countOf(err)
+	c.metrics.Deduped++
+}
+
+// onceEntry is the warning kept for a code if OncePerCode is set.
+type onceEntry struct {
+	i int      // index in List.Warnings
+	w *Warning // copy in List.Warnings[i] counting repeats, once repeated
+}
+
+// countWarning increments the counter for the code of err in c.Counters.
+// Warnings without a code are counted under "-".
+func (c *Collector) countWarning(err error) {
+	if c.Counters == nil {
+		return
+	}
+	code := codeOf(err)
+	if code == "" {
+		code = "-"
+	}
+	c.Counters.Add(code, 1)
+}
+
 func (c *Collector) erorr() error {
 	if !c.FatalWithWarnings && c.l.Fatal != nil {
-		return c.l.Fatal
+		if c.l.count() == 0 {
+			return c.l.Fatal
+		}
+		return &FatalError{List: c.l}
 	}
-	if c.l.Fatal == nil && len(c.l.Warnings) == 0 {
+	if c.l.Fatal == nil && c.l.count() == 0 {
 		return nil
 	}
 	// Note that a single warning is also returned as a List. This is to make it
@@ -173,10 +527,27 @@ func (c *Collector) erorr() error {
 	return c.l
 }
 
+// A FatalError is returned by a Collector in place of a fatal error when
+// FatalWithWarnings is false but warnings have been collected. Its message is
+// that of the fatal error, and it unwraps to the fatal error; the warnings are
+// available in List.
+type FatalError struct {
+	List List
+}
+
+// Error implements the error interface.
+func (e *FatalError) Error() string { return e.List.Fatal.Error() }
+
+// Unwrap returns the fatal error.
+func (e *FatalError) Unwrap() error { return e.List.Fatal }
+
 // FatalOnly returns the fatal error, if any, **in an error returned by a
 // Collector**. It returns nil if and only if err is nil or err is a List
 // with err.Fatal == nil.
 func FatalOnly(err error) error {
+	if fe, ok := err.(*FatalError); ok {
+		return fe.List.Fatal
+	}
 	l, ok := err.(List)
 	if !ok {
 		return err
@@ -192,3 +563,35 @@ func WarningsOnly(err error) []error {
 	}
 	return l.Warnings
 }
+
+// ExitCode returns an exit status for a program ending with err: 1 if err
+// holds a fatal error, and 0 otherwise (i.e. if err is nil or holds only
+// warnings).
+func ExitCode(err error) int {
+	if fatal, _ := Split(err); fatal != nil {
+		return 1
+	}
+	return 0
+}
+
+// Split returns both the fatal error and the warnings in err, which may be a
+// List or *FatalError returned by a Collector, or an error wrapping one of
+// them. For a *FatalError, the warnings it carries are returned; for a
+// *WrappedError, the wrapped error is returned as the fatal error, together
+// with the warnings of its List. Any other error is returned as the fatal
+// error, with no warnings.
+func Split(err error) (fatal error, warnings []error) {
+	var we *WrappedError
+	if errors.As(err, &we) {
+		return we.Err, we.List.Warnings
+	}
+	var fe *FatalError
+	if errors.As(err, &fe) {
+		return fe.List.Fatal, fe.List.Warnings
+	}
+	var l List
+	if errors.As(err, &l) {
+		return l.Fatal, l.Warnings
+	}
+	return err, nil
+}
@@ -1,7 +1,10 @@
 package warnings_test
 
 import (
+	"bytes"
 	"errors"
+	"expvar"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 )
 
 var _ error = w.List{}
+var _ w.Interface = &w.Collector{}
 
 type warn string
 
@@ -80,3 +84,250 @@ func TestCollector(t *testing.T) {
 		}
 	}
 }
+
+func TestDrainTo(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.FatalWithWarnings = true
+	c.Collect(warning("1w"))
+	c.Collect(warning("2w"))
+	b := bytes.NewBuffer(nil)
+	if err := c.DrainTo(b); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1w\n2w\n"; b.String() != want {
+		t.Errorf("DrainTo() wrote %q; want %q", b, want)
+	}
+	c.Collect(warning("3w"))
+	err := c.Done()
+	l, ok := err.(w.List)
+	if !ok || l.Drained != 2 || !reflect.DeepEqual(l.Warnings, []error{warning("3w")}) {
+		t.Fatalf("Done() = %#v; want List with 2 drained and 1 warning", err)
+	}
+	want := "warnings:\n(2 earlier warning(s) already written)\n3w\n"
+	if l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+}
+
+func TestDrainToOnly(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"))
+	c.DrainTo(bytes.NewBuffer(nil))
+	if err := c.Done(); err == nil || w.FatalOnly(err) != nil {
+		t.Errorf("Done() = %v; want List with drained warnings", err)
+	}
+}
+
+func TestFatalError(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"))
+	f := fatal("2f")
+	err := c.Collect(f)
+	if err.Error() != f.Error() || !errors.Is(err, f) {
+		t.Fatalf("Collect(%v) = %v; want wrapped %v", f, err, f)
+	}
+	var fe *w.FatalError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Collect(%v) = %#v; want *FatalError", f, err)
+	}
+	if want := []error{warning("1w")}; !reflect.DeepEqual(fe.List.Warnings, want) {
+		t.Errorf("FatalError warnings = %v; want %v", fe.List.Warnings, want)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	f := fatal("2f")
+	ws := []error{warning("1w")}
+	l := w.List{Warnings: ws, Fatal: f}
+	tests := []struct {
+		err   error
+		fatal error
+		warns []error
+	}{
+		{nil, nil, nil},
+		{f, f, nil},
+		{l, f, ws},
+		{w.List{Warnings: ws}, nil, ws},
+		{&w.FatalError{List: l}, f, ws},
+		{fmt.Errorf("context: %w", l), f, ws},
+	}
+	for _, tt := range tests {
+		fatal, warns := w.Split(tt.err)
+		if fatal != tt.fatal || !reflect.DeepEqual(warns, tt.warns) {
+			t.Errorf("Split(%v) = %v, %v; want %v, %v", tt.err, fatal, warns,
+				tt.fatal, tt.warns)
+		}
+	}
+}
+
+func TestWith(t *testing.T) {
+	f := fatal("2f")
+	tests := []struct {
+		fn    func(c *w.Collector) error
+		fatal error
+		warns []error
+	}{
+		{func(c *w.Collector) error {
+			return c.Collect(warning("1w"))
+		}, nil, []error{warning("1w")}},
+		{func(c *w.Collector) error {
+			if err := c.Collect(warning("1w")); err != nil {
+				return err
+			}
+			return c.Collect(f)
+		}, f, []error{warning("1w")}},
+		{func(c *w.Collector) error {
+			c.Collect(warning("1w"))
+			return f
+		}, f, []error{warning("1w")}},
+		{func(c *w.Collector) error {
+			return nil
+		}, nil, nil},
+	}
+	for i, tt := range tests {
+		fatal, warns := w.Split(w.With(isFatal, tt.fn))
+		if fatal != tt.fatal || !reflect.DeepEqual(warns, tt.warns) {
+			t.Errorf("%d: With() = %v, %v; want %v, %v", i, fatal, warns,
+				tt.fatal, tt.warns)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{w.List{Warnings: []error{warning("1w")}}, 0},
+		{fatal("1f"), 1},
+		{w.List{Warnings: []error{warning("1w")}, Fatal: fatal("2f")}, 1},
+	}
+	for _, tt := range tests {
+		if got := w.ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d; want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestMaxWarnings(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.MaxWarnings = 2
+	c.Ignore = func(err error) bool { return err == warning("ignored") }
+	for _, s := range []string{"1w", "ignored", "2w", "3w", "4w"} {
+		c.Collect(warning(s))
+	}
+	l, ok := c.Done().(w.List)
+	if !ok || l.Omitted != 2 ||
+		!reflect.DeepEqual(l.Warnings, []error{warning("1w"), warning("2w")}) {
+		t.Fatalf("Done() = %#v; want 2 warnings and 2 omitted", l)
+	}
+	want := "warnings:\n1w\n2w\n(2 more warning(s) omitted)\n"
+	if l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	counters := new(expvar.Map)
+	for i := 0; i < 2; i++ {
+		c := w.NewCollector(isFatalStructured)
+		c.Counters = counters
+		c.Collect(&w.Warning{Code: "C1"})
+		c.Collect(warning("1w"))
+		c.Collect(&w.Warning{Code: "C1"})
+		c.Collect(fatal("2f"))
+	}
+	want := `{"-": 2, "C1": 4}`
+	if got := counters.String(); got != want {
+		t.Errorf("Counters = %s; want %s", got, want)
+	}
+}
+
+func TestOncePerCode(t *testing.T) {
+	c := w.NewCollector(isFatalStructured)
+	c.OncePerCode = true
+	first := &w.Warning{Code: "C1", Msg: "a"}
+	c.Collect(first)
+	c.Collect(warning("1w"))
+	c.Collect(&w.Warning{Code: "C1", Msg: "b"})
+	c.Collect(&w.Warning{Code: "C2", Msg: "c"})
+	c.Collect(&w.Warning{Code: "C1", Msg: "d", Count: 3})
+	c.Collect(warning("1w"))
+	l := c.Done().(w.List)
+	want := "warnings:\n[C1] a (4 more times)\n1w\n[C2] c\n1w\n"
+	if l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+	if first.Count != 0 {
+		t.Errorf("collected *Warning modified: Count = %d", first.Count)
+	}
+	if got := l.Stats()["C1"]; got != 5 {
+		t.Errorf("Stats()[C1] = %d; want 5", got)
+	}
+}
+
+func TestCollectorAccessors(t *testing.T) {
+	c := w.NewCollector(isFatal)
+	c.Collect(warning("1w"))
+	warns := c.Warnings()
+	if want := []error{warning("1w")}; !reflect.DeepEqual(warns, want) ||
+		c.Fatal() != nil {
+		t.Errorf("Warnings(), Fatal() = %v, %v; want %v, nil", warns,
+			c.Fatal(), want)
+	}
+	warns[0] = warning("modified")
+	c.Collect(warning("2w"))
+	f := fatal("3f")
+	c.Collect(f)
+	want := []error{warning("1w"), warning("2w")}
+	if got := c.Warnings(); !reflect.DeepEqual(got, want) || c.Fatal() != f {
+		t.Errorf("Warnings(), Fatal() = %v, %v; want %v, %v", got, c.Fatal(),
+			want, f)
+	}
+}
+
+func TestListMethods(t *testing.T) {
+	warns := []error{warning("1w"), nil, warning("2w")}
+	l := w.NewList(warns, nil)
+	warns[0] = warning("modified")
+	if l.Len() != 2 || l.WarningAt(0) != warning("1w") ||
+		l.WarningAt(1) != warning("2w") {
+		t.Errorf("NewList() = %v; want warnings 1w, 2w", l.Warnings)
+	}
+	l.AddWarning(nil)
+	l.AddWarning(warning("3w"))
+	f := fatal("4f")
+	l.SetFatal(f)
+	if l.Len() != 3 || l.WarningAt(2) != warning("3w") || l.Fatal != f {
+		t.Errorf("List = %v; want warnings 1w, 2w, 3w and fatal 4f", l)
+	}
+}
+
+func TestListFatals(t *testing.T) {
+	var l w.List
+	if l.FatalErrors() != nil {
+		t.Errorf("FatalErrors() = %v; want nil", l.FatalErrors())
+	}
+	f1, f2 := fatal("1f"), fatal("2f")
+	l.AddFatal(nil)
+	l.AddFatal(f1)
+	if got := l.FatalErrors(); len(got) != 1 || got[0] != f1 || l.Fatals != nil {
+		t.Errorf("FatalErrors() = %v, Fatals %v; want [1f], nil", got, l.Fatals)
+	}
+	l.AddFatal(f2)
+	l.AddWarning(warning("3w"))
+	if got := l.FatalErrors(); len(got) != 2 || l.Fatal != f1 {
+		t.Errorf("FatalErrors() = %v, Fatal %v; want [1f 2f], 1f", got, l.Fatal)
+	}
+	if want := "fatal:\n1f\n2f\nwarning:\n3w\n"; l.Error() != want {
+		t.Errorf("Error() = %q; want %q", l.Error(), want)
+	}
+	if want := "fatal: 1f; 2f (warning: 3w)"; l.Compact() != want {
+		t.Errorf("Compact() = %q; want %q", l.Compact(), want)
+	}
+	l.SetFatal(f2)
+	if got := l.FatalErrors(); len(got) != 1 || got[0] != f2 {
+		t.Errorf("FatalErrors() after SetFatal = %v; want [2f]", got)
+	}
+}
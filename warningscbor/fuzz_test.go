@@ -0,0 +1,56 @@
+//go:build go1.18
+// +build go1.18
+
+package warningscbor_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningscbor"
+)
+
+// FuzzUnmarshal checks that Unmarshal doesn't panic on any input, and that
+// the Lists it decodes round trip.
+func FuzzUnmarshal(f *testing.F) {
+	for _, s := range []string{
+		"a2676d657373616765616d646c696e6502",
+		"c0bf676d6573736167657f616d6131ffff",
+		"a2676d657373616765616d617884f93e0022f6f5",
+	} {
+		data, _ := hex.DecodeString(s)
+		f.Add(data)
+	}
+	var l warnings.List
+	l.AddSection("s", warnings.List{Warnings: []error{errors.New("1w")}})
+	l.AddFatal(&warnings.Warning{Code: "C2", Msg: "2f",
+		Attrs: []warnings.Attr{warnings.Field("k", []int{1})}})
+	data, err := warningscbor.Marshal(l)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var w warnings.Warning
+		warningscbor.Unmarshal(data, &w)
+		var l warnings.List
+		if err := warningscbor.Unmarshal(data, &l); err != nil {
+			return
+		}
+		data, err := warningscbor.Marshal(l)
+		if err != nil {
+			t.Fatalf("Marshal(%v) = %v", l, err)
+		}
+		var l2 warnings.List
+		if err := warningscbor.Unmarshal(data, &l2); err != nil {
+			t.Fatalf("Unmarshal(Marshal(%v)) = %v", l, err)
+		}
+		js, _ := json.Marshal(l)
+		if js2, _ := json.Marshal(l2); string(js2) != string(js) {
+			t.Errorf("round trip = %s; want %s", js2, js)
+		}
+	})
+}
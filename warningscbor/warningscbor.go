@@ -0,0 +1,854 @@
+// Package warningscbor encodes warnings.List and warnings.Warning values in
+// CBOR (RFC 8949), a compact binary alternative to their JSON format, e.g.
+// for event buses carrying large numbers of warnings.
+//
+// The encoding has the same structure as the JSON format (see
+// warnings.List.MarshalJSON and warnings.WireVersion), with JSON objects
+// encoded as CBOR maps with text keys, so that the two formats evolve
+// together. Values are encoded and decoded directly rather than by way of
+// JSON, except for attribute values other than booleans, numbers, strings
+// and byte slices, which are encoded like their JSON representation:
+//
+//	data, err := warningscbor.Marshal(l)
+//	...
+//	var l warnings.List
+//	err := warningscbor.Unmarshal(data, &l)
+//
+// The package implements the subset of CBOR it needs itself, so as not to
+// add a dependency to the module, which supports Go versions predating
+// modules. Decoding accepts well-formed input with tags (which are ignored)
+// and indefinite lengths, but limits the nesting of arrays and maps to a
+// depth of 1000, so that untrusted input can't exhaust the stack.
+package warningscbor // import "gopkg.in/warnings.v0/warningscbor"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/warnings.v0"
+)
+
+// CBOR major types.
+const (
+	majorUint  = 0
+	majorNeg   = 1
+	majorBytes = 2
+	majorText  = 3
+	majorArray = 4
+	majorMap   = 5
+	majorTag   = 6
+	majorOther = 7
+)
+
+// Simple values and other special encodings of major type 7.
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+	argFloat16  = 25
+	argFloat32  = 26
+	argFloat64  = 27
+	argIndef    = 31
+	breakByte   = 0xff
+)
+
+// Marshal returns the CBOR encoding of v, which is a warnings.List or a
+// *warnings.Warning (or a *warnings.List or a warnings.Warning).
+func Marshal(v interface{}) ([]byte, error) {
+	e := &encoder{}
+	switch v := v.(type) {
+	case warnings.List:
+		e.list(v, true)
+	case *warnings.List:
+		e.list(*v, true)
+	case *warnings.Warning:
+		e.warning(v)
+	case warnings.Warning:
+		e.warning(&v)
+	default:
+		return nil, fmt.Errorf("warningscbor: unsupported type %T", v)
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.b.Bytes(), nil
+}
+
+// Unmarshal decodes the CBOR encoded data into v, which is a *warnings.List
+// or a *warnings.Warning. As for JSON, errors (including fatal ones) are
+// restored as *warnings.Warning values, except for sections, which are restored as
+// *warnings.Section values. Lists of a newer wire version than
+// warnings.WireVersion are rejected.
+func Unmarshal(data []byte, v interface{}) error {
+	d := decoder{data: data}
+	var l warnings.List
+	var w *warnings.Warning
+	var err error
+	switch v.(type) {
+	case *warnings.List:
+		l, err = d.list(true)
+	case *warnings.Warning:
+		w, err = d.warning()
+	default:
+		return fmt.Errorf("warningscbor: unsupported type %T", v)
+	}
+	if err != nil {
+		return err
+	}
+	if d.off != len(data) {
+		return errors.New("warningscbor: trailing data")
+	}
+	switch v := v.(type) {
+	case *warnings.List:
+		*v = l
+	case *warnings.Warning:
+		*v = *w
+	}
+	return nil
+}
+
+// An encoder encodes Lists and Warnings, recording the first error.
+type encoder struct {
+	b   bytes.Buffer
+	err error
+}
+
+// pairs writes the head of a map with as many pairs as there are true
+// values in present.
+func (e *encoder) pairs(present ...bool) {
+	n := 0
+	for _, ok := range present {
+		if ok {
+			n++
+		}
+	}
+	writeHead(&e.b, majorMap, uint64(n))
+}
+
+func (e *encoder) text(s string) {
+	writeHead(&e.b, majorText, uint64(len(s)))
+	e.b.WriteString(s)
+}
+
+func (e *encoder) int(i int64) {
+	if i < 0 {
+		writeHead(&e.b, majorNeg, uint64(-(i + 1)))
+	} else {
+		writeHead(&e.b, majorUint, uint64(i))
+	}
+}
+
+func (e *encoder) float(f float64) {
+	var buf [9]byte
+	buf[0] = majorOther<<5 | argFloat64
+	binary.BigEndian.PutUint64(buf[1:], math.Float64bits(f))
+	e.b.Write(buf[:])
+}
+
+// textField and intField write a key and a value.
+func (e *encoder) textField(key, s string)    { e.text(key); e.text(s) }
+func (e *encoder) intField(key string, i int) { e.text(key); e.int(int64(i)) }
+
+// list writes l, with the wire version if top is set (i.e. unless l is a
+// section).
+func (e *encoder) list(l warnings.List, top bool) {
+	e.pairs(top, len(l.Warnings) > 0, l.Fatal != nil, len(l.Fatals) > 0,
+		l.Drained != 0, l.Omitted != 0, l.Expired != 0, l.Total != 0)
+	if top {
+		e.intField("version", warnings.WireVersion)
+	}
+	if len(l.Warnings) > 0 {
+		e.text("warnings")
+		writeHead(&e.b, majorArray, uint64(len(l.Warnings)))
+		for _, err := range l.Warnings {
+			e.error(err)
+		}
+	}
+	if l.Fatal != nil {
+		e.text("fatal")
+		e.error(l.Fatal)
+	}
+	if len(l.Fatals) > 0 {
+		e.text("fatals")
+		writeHead(&e.b, majorArray, uint64(len(l.Fatals)))
+		for _, err := range l.Fatals {
+			e.error(err)
+		}
+	}
+	for _, f := range []struct {
+		key string
+		n   int
+	}{{"drained", l.Drained}, {"omitted", l.Omitted}, {"expired", l.Expired},
+		{"total", l.Total}} {
+		if f.n != 0 {
+			e.intField(f.key, f.n)
+		}
+	}
+}
+
+// error writes err: a *Warning (also if wrapped in a *CausedError), a
+// *Section, or any other error as its message.
+func (e *encoder) error(err error) {
+	for {
+		ce, ok := err.(*warnings.CausedError)
+		if !ok {
+			break
+		}
+		err = ce.Err
+	}
+	switch err := err.(type) {
+	case *warnings.Warning:
+		e.warning(err)
+	case *warnings.Section:
+		e.pairs(true, true)
+		e.textField("message", err.Label)
+		e.text("section")
+		e.list(err.List, false)
+	default:
+		e.pairs(true)
+		e.textField("message", err.Error())
+	}
+}
+
+// warning writes w.
+func (e *encoder) warning(w *warnings.Warning) {
+	attrs := make(map[string]interface{}, len(w.Attrs))
+	for _, a := range w.Attrs {
+		attrs[a.Key] = a.Value
+	}
+	e.pairs(w.Code != "", w.Severity != warnings.Warn, true, w.Pos.Filename != "",
+		w.Pos.Line != 0, w.Pos.Column != 0, w.Hint != "", w.Err != nil,
+		w.Count != 0, w.Priority != 0, len(attrs) > 0)
+	if w.Code != "" {
+		e.textField("code", w.Code)
+	}
+	if w.Severity != warnings.Warn {
+		e.textField("severity", w.Severity.String())
+	}
+	e.textField("message", w.Msg)
+	if w.Pos.Filename != "" {
+		e.textField("file", w.Pos.Filename)
+	}
+	if w.Pos.Line != 0 {
+		e.intField("line", w.Pos.Line)
+	}
+	if w.Pos.Column != 0 {
+		e.intField("column", w.Pos.Column)
+	}
+	if w.Hint != "" {
+		e.textField("hint", w.Hint)
+	}
+	if w.Err != nil {
+		e.textField("cause", w.Err.Error())
+	}
+	if w.Count != 0 {
+		e.intField("count", w.Count)
+	}
+	if w.Priority != 0 {
+		e.intField("priority", w.Priority)
+	}
+	if len(attrs) > 0 {
+		keys := make([]string, 0, len(attrs))
+		for k := range attrs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		e.text("attrs")
+		writeHead(&e.b, majorMap, uint64(len(keys)))
+		for _, k := range keys {
+			e.text(k)
+			e.value(attrs[k])
+		}
+	}
+}
+
+// value writes the attribute value v.
+func (e *encoder) value(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		e.b.WriteByte(majorOther<<5 | simpleNull)
+	case bool:
+		if v {
+			e.b.WriteByte(majorOther<<5 | simpleTrue)
+		} else {
+			e.b.WriteByte(majorOther<<5 | simpleFalse)
+		}
+	case string:
+		e.text(v)
+	case []byte:
+		writeHead(&e.b, majorBytes, uint64(len(v)))
+		e.b.Write(v)
+	case int:
+		e.int(int64(v))
+	case int8:
+		e.int(int64(v))
+	case int16:
+		e.int(int64(v))
+	case int32:
+		e.int(int64(v))
+	case int64:
+		e.int(v)
+	case uint:
+		writeHead(&e.b, majorUint, uint64(v))
+	case uint8:
+		writeHead(&e.b, majorUint, uint64(v))
+	case uint16:
+		writeHead(&e.b, majorUint, uint64(v))
+	case uint32:
+		writeHead(&e.b, majorUint, uint64(v))
+	case uint64:
+		writeHead(&e.b, majorUint, v)
+	case float32:
+		e.float(float64(v))
+	case float64:
+		e.float(v)
+	default:
+		e.jsonValue(v)
+	}
+}
+
+// jsonValue writes v like its JSON representation.
+func (e *encoder) jsonValue(v interface{}) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		var jv interface{}
+		if err = d.Decode(&jv); err == nil {
+			err = encode(&e.b, jv)
+		}
+	}
+	if err != nil && e.err == nil {
+		e.err = err
+	}
+}
+
+// encode writes the CBOR encoding of the JSON value v to b.
+func encode(b *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		b.WriteByte(majorOther<<5 | simpleNull)
+	case bool:
+		if v {
+			b.WriteByte(majorOther<<5 | simpleTrue)
+		} else {
+			b.WriteByte(majorOther<<5 | simpleFalse)
+		}
+	case json.Number:
+		return encodeNumber(b, v)
+	case string:
+		writeHead(b, majorText, uint64(len(v)))
+		b.WriteString(v)
+	case []interface{}:
+		writeHead(b, majorArray, uint64(len(v)))
+		for _, e := range v {
+			if err := encode(b, e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeHead(b, majorMap, uint64(len(v)))
+		for _, k := range keys {
+			writeHead(b, majorText, uint64(len(k)))
+			b.WriteString(k)
+			if err := encode(b, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("warningscbor: unexpected JSON value of type %T", v)
+	}
+	return nil
+}
+
+// encodeNumber writes n as a CBOR integer if possible, and otherwise as a
+// float.
+func encodeNumber(b *bytes.Buffer, n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		if i < 0 {
+			writeHead(b, majorNeg, uint64(-(i + 1)))
+		} else {
+			writeHead(b, majorUint, uint64(i))
+		}
+		return nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		writeHead(b, majorUint, u)
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return err
+	}
+	b.WriteByte(majorOther<<5 | argFloat64)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(f))
+	b.Write(buf[:])
+	return nil
+}
+
+// writeHead writes the initial byte and argument of a data item.
+func writeHead(b *bytes.Buffer, major byte, arg uint64) {
+	var buf [9]byte
+	switch {
+	case arg < 24:
+		b.WriteByte(major<<5 | byte(arg))
+		return
+	case arg <= math.MaxUint8:
+		buf[0], buf[1] = major<<5|24, byte(arg)
+		b.Write(buf[:2])
+	case arg <= math.MaxUint16:
+		buf[0] = major<<5 | 25
+		binary.BigEndian.PutUint16(buf[1:], uint16(arg))
+		b.Write(buf[:3])
+	case arg <= math.MaxUint32:
+		buf[0] = major<<5 | 26
+		binary.BigEndian.PutUint32(buf[1:], uint32(arg))
+		b.Write(buf[:5])
+	default:
+		buf[0] = major<<5 | 27
+		binary.BigEndian.PutUint64(buf[1:], arg)
+		b.Write(buf[:9])
+	}
+}
+
+// list decodes a List, checking the wire version if top is set (i.e. unless
+// the List is a section).
+func (d *decoder) list(top bool) (warnings.List, error) {
+	var l warnings.List
+	var fatal error
+	var fatals []error
+	err := d.fields(func(key string) error {
+		var err error
+		switch key {
+		case "version":
+			var v int
+			v, err = d.int()
+			if err == nil && top && v > warnings.WireVersion {
+				err = fmt.Errorf("warningscbor: unsupported wire version %d (want at most %d)",
+					v, warnings.WireVersion)
+			}
+		case "warnings":
+			err = d.elems(func() error {
+				werr, err := d.error()
+				l.Warnings = append(l.Warnings, werr)
+				return err
+			})
+		case "fatal":
+			fatal, err = d.error()
+		case "fatals":
+			err = d.elems(func() error {
+				ferr, err := d.error()
+				fatals = append(fatals, ferr)
+				return err
+			})
+		case "drained":
+			l.Drained, err = d.int()
+		case "omitted":
+			l.Omitted, err = d.int()
+		case "expired":
+			l.Expired, err = d.int()
+		case "total":
+			l.Total, err = d.int()
+		default:
+			_, err = d.value()
+		}
+		return err
+	})
+	if err != nil {
+		return warnings.List{}, err
+	}
+	l.Fatal = fatal
+	for i, ferr := range fatals {
+		if i == 0 && l.Fatal != nil {
+			l.Fatals = append(l.Fatals, l.Fatal)
+			continue
+		}
+		l.AddFatal(ferr)
+	}
+	return l, nil
+}
+
+// error decodes a *Warning, or a *Section if it has a section.
+func (d *decoder) error() (error, error) {
+	w, section, err := d.warningOrSection()
+	if err != nil || section == nil {
+		return w, err
+	}
+	return &warnings.Section{Label: w.Msg, List: *section}, nil
+}
+
+// warning decodes a *Warning, ignoring any section.
+func (d *decoder) warning() (*warnings.Warning, error) {
+	w, _, err := d.warningOrSection()
+	return w, err
+}
+
+// warningOrSection decodes a *Warning, and its section, if any.
+func (d *decoder) warningOrSection() (*warnings.Warning, *warnings.List, error) {
+	w := new(warnings.Warning)
+	var section *warnings.List
+	err := d.fields(func(key string) error {
+		var err error
+		switch key {
+		case "code":
+			w.Code, err = d.text()
+		case "severity":
+			var s string
+			if s, err = d.text(); err == nil {
+				err = w.Severity.UnmarshalText([]byte(s))
+			}
+		case "message":
+			w.Msg, err = d.text()
+		case "file":
+			w.Pos.Filename, err = d.text()
+		case "line":
+			w.Pos.Line, err = d.int()
+		case "column":
+			w.Pos.Column, err = d.int()
+		case "hint":
+			w.Hint, err = d.text()
+		case "cause":
+			var s string
+			if s, err = d.text(); err == nil && s != "" {
+				w.Err = errors.New(s)
+			}
+		case "count":
+			w.Count, err = d.int()
+		case "priority":
+			w.Priority, err = d.int()
+		case "attrs":
+			attrs := make(map[string]interface{})
+			err = d.fields(func(key string) error {
+				v, err := d.value()
+				attrs[key] = v
+				return err
+			})
+			for k, v := range attrs {
+				w.Attrs = append(w.Attrs, warnings.Attr{Key: k, Value: v})
+			}
+			sort.Slice(w.Attrs, func(i, j int) bool {
+				return w.Attrs[i].Key < w.Attrs[j].Key
+			})
+		case "section":
+			var l warnings.List
+			if l, err = d.list(false); err == nil {
+				section = &l
+			}
+		default:
+			_, err = d.value()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, section, nil
+}
+
+// next decodes the initial byte and argument of the next data item, skipping
+// tags.
+func (d *decoder) next() (major, info byte, arg uint64, err error) {
+	for {
+		major, info, arg, err = d.head()
+		if err != nil || major != majorTag {
+			return major, info, arg, err
+		}
+	}
+}
+
+// fields decodes a map with text keys, calling f for each key to decode the
+// value.
+func (d *decoder) fields(f func(key string) error) error {
+	major, info, n, err := d.next()
+	if err != nil {
+		return err
+	}
+	if major != majorMap {
+		return errors.New("warningscbor: map expected")
+	}
+	if err := d.enter(); err != nil {
+		return err
+	}
+	defer d.leave()
+	indef := info == argIndef
+	for i := uint64(0); indef && !d.atBreak() || !indef && i < n; i++ {
+		key, err := d.text()
+		if err != nil {
+			return err
+		}
+		if err := f(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// elems decodes an array, calling f to decode each element.
+func (d *decoder) elems(f func() error) error {
+	major, info, n, err := d.next()
+	if err != nil {
+		return err
+	}
+	if major != majorArray {
+		return errors.New("warningscbor: array expected")
+	}
+	if err := d.enter(); err != nil {
+		return err
+	}
+	defer d.leave()
+	indef := info == argIndef
+	for i := uint64(0); indef && !d.atBreak() || !indef && i < n; i++ {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text decodes a text string.
+func (d *decoder) text() (string, error) {
+	major, info, n, err := d.next()
+	if err != nil {
+		return "", err
+	}
+	if major != majorText {
+		return "", errors.New("warningscbor: text string expected")
+	}
+	return d.str(major, n, info == argIndef)
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// int decodes an integer fitting in an int.
+func (d *decoder) int() (int, error) {
+	major, _, arg, err := d.next()
+	switch {
+	case err != nil:
+		return 0, err
+	case major != majorUint && major != majorNeg:
+		return 0, errors.New("warningscbor: integer expected")
+	case arg > uint64(maxInt):
+		return 0, errors.New("warningscbor: integer out of range")
+	case major == majorNeg:
+		return -int(arg) - 1, nil
+	}
+	return int(arg), nil
+}
+
+// errTruncated is returned for data ending in the middle of a data item.
+var errTruncated = errors.New("warningscbor: unexpected end of data")
+
+// maxDepth limits the nesting of arrays and maps.
+const maxDepth = 1000
+
+// A decoder decodes CBOR data items.
+type decoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+// value decodes the next data item into a value such as returned by
+// json.Unmarshal, except for integers, which are returned as int64 (or uint64
+// if too large), and byte strings, which are returned as []byte.
+func (d *decoder) value() (interface{}, error) {
+	major, info, arg, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	indef := info == argIndef
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case majorNeg:
+		if arg > math.MaxInt64 {
+			return -1 - float64(arg), nil
+		}
+		return -int64(arg) - 1, nil
+	case majorBytes, majorText:
+		s, err := d.str(major, arg, indef)
+		if err != nil {
+			return nil, err
+		}
+		if major == majorBytes {
+			return []byte(s), nil
+		}
+		return s, nil
+	case majorArray:
+		return d.array(arg, indef)
+	case majorMap:
+		return d.object(arg, indef)
+	}
+	switch info {
+	case simpleFalse:
+		return false, nil
+	case simpleTrue:
+		return true, nil
+	case simpleNull, simpleNull + 1: // null, undefined
+		return nil, nil
+	case argFloat16:
+		return float16(uint16(arg)), nil
+	case argFloat32:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case argFloat64:
+		return math.Float64frombits(arg), nil
+	}
+	return nil, fmt.Errorf("warningscbor: unsupported simple value %d", info)
+}
+
+// head decodes the initial byte and argument of the next data item.
+func (d *decoder) head() (major, info byte, arg uint64, err error) {
+	if d.off >= len(d.data) {
+		return 0, 0, 0, errTruncated
+	}
+	b := d.data[d.off]
+	d.off++
+	major, info = b>>5, b&0x1f
+	var n int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		n = 1 << (info - 24)
+	case info == argIndef && major >= majorBytes && major <= majorMap:
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("warningscbor: invalid initial byte %#x", b)
+	}
+	if len(d.data)-d.off < n {
+		return 0, 0, 0, errTruncated
+	}
+	for _, c := range d.data[d.off : d.off+n] {
+		arg = arg<<8 | uint64(c)
+	}
+	d.off += n
+	return major, info, arg, nil
+}
+
+// str decodes the content of a byte or text string of length n, or of an
+// indefinite length one.
+func (d *decoder) str(major byte, n uint64, indef bool) (string, error) {
+	if !indef {
+		if uint64(len(d.data)-d.off) < n {
+			return "", errTruncated
+		}
+		s := string(d.data[d.off : d.off+int(n)])
+		d.off += int(n)
+		return s, nil
+	}
+	var s strings.Builder
+	for !d.atBreak() {
+		m, info, n, err := d.head()
+		if err != nil {
+			return "", err
+		}
+		if m != major || info == argIndef {
+			return "", errors.New("warningscbor: invalid string chunk")
+		}
+		chunk, err := d.str(major, n, false)
+		if err != nil {
+			return "", err
+		}
+		s.WriteString(chunk)
+	}
+	return s.String(), nil
+}
+
+// array decodes the elements of an array of length n, or of an indefinite
+// length one.
+func (d *decoder) array(n uint64, indef bool) (interface{}, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+	a := []interface{}{}
+	for i := uint64(0); indef && !d.atBreak() || !indef && i < n; i++ {
+		v, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+	}
+	return a, nil
+}
+
+// object decodes the pairs of a map of n pairs, or of an indefinite length
+// one. Keys must be text strings.
+func (d *decoder) object(n uint64, indef bool) (interface{}, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+	m := make(map[string]interface{})
+	for i := uint64(0); indef && !d.atBreak() || !indef && i < n; i++ {
+		k, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("warningscbor: unsupported map key %v", k)
+		}
+		if m[key], err = d.value(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// atBreak reports whether the next byte is a break, and if so, skips it. At
+// the end of data, it reports false, so that decoding fails.
+func (d *decoder) atBreak() bool {
+	if d.off < len(d.data) && d.data[d.off] == breakByte {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *decoder) enter() error {
+	if d.depth++; d.depth > maxDepth {
+		return errors.New("warningscbor: nesting too deep")
+	}
+	return nil
+}
+
+func (d *decoder) leave() { d.depth-- }
+
+// float16 returns the value of the IEEE 754 half-precision float h.
+func float16(h uint16) float64 {
+	exp, frac := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(frac+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
@@ -0,0 +1,175 @@
+package warningscbor_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningscbor"
+)
+
+func TestRoundTrip(t *testing.T) {
+	l := warnings.List{
+		Warnings: []error{
+			errors.New("1w"),
+			&warnings.Warning{Code: "C2", Severity: warnings.Info, Msg: "2w",
+				Pos: warnings.Position{Filename: "f", Line: 300, Column: 70000}, Count: -1,
+				Attrs: []warnings.Attr{warnings.Field("ratio", 0.5)}},
+		},
+		Fatal:   &warnings.Warning{Msg: "3f", Err: errors.New("cause")},
+		Omitted: 1 << 33,
+	}
+	data, err := warningscbor.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, _ := json.Marshal(l)
+	if len(data) >= len(js) {
+		t.Errorf("Marshal() = %d bytes; want fewer than %d for JSON", len(data), len(js))
+	}
+	var got warnings.List
+	if err := warningscbor.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if gjs, _ := json.Marshal(got); string(gjs) != string(js) {
+		t.Errorf("round trip = %s; want %s", gjs, js)
+	}
+}
+
+func TestRoundTripSection(t *testing.T) {
+	l := warnings.List{Warnings: []error{
+		&warnings.Section{Label: "s", List: warnings.List{
+			Warnings: []error{&warnings.Warning{Msg: "1w",
+				Attrs: []warnings.Attr{warnings.Field("p", struct{ X int }{1})}}},
+			Drained: 2,
+		}},
+		&warnings.CausedError{Err: &warnings.Warning{Msg: "2w", Severity: warnings.Error}, Cause: errors.New("c")},
+	}}
+	data, err := warningscbor.Marshal(&l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got warnings.List
+	if err := warningscbor.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Warnings[0].(*warnings.Section); !ok {
+		t.Errorf("Warnings[0] = %T; want *warnings.Section", got.Warnings[0])
+	}
+	js, _ := json.Marshal(l)
+	if gjs, _ := json.Marshal(got); string(gjs) != string(js) {
+		t.Errorf("round trip = %s; want %s", gjs, js)
+	}
+}
+
+func TestUnmarshalDeep(t *testing.T) {
+	// {"message": "m", "x": [[[...]]]}, nested too deep
+	data, _ := hex.DecodeString("a2676d657373616765616d6178")
+	data = append(data, bytes.Repeat([]byte{0x81}, 2000)...)
+	data = append(data, 0x00)
+	if err := warningscbor.Unmarshal(data, new(warnings.Warning)); err == nil {
+		t.Error("Unmarshal(nested too deep) = nil; want an error")
+	}
+	// {"message": tag(tag(...("m")))}, with a million tags
+	data, _ = hex.DecodeString("a1676d657373616765")
+	data = append(data, bytes.Repeat([]byte{0xc0}, 1e6)...)
+	data = append(data, 0x61, 'm')
+	var w warnings.Warning
+	if err := warningscbor.Unmarshal(data, &w); err != nil || w.Msg != "m" {
+		t.Errorf("Unmarshal(tagged) = %v, %q; want m", err, w.Msg)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	if _, err := warningscbor.Marshal("x"); err == nil {
+		t.Error("Marshal(string) = nil error; want an error")
+	}
+	if err := warningscbor.Unmarshal([]byte{0xa0}, new(string)); err == nil {
+		t.Error("Unmarshal(*string) = nil; want an error")
+	}
+	// {"version": WireVersion+1}
+	data := []byte{0xa1, 0x67, 'v', 'e', 'r', 's', 'i', 'o', 'n', byte(warnings.WireVersion + 1)}
+	if err := warningscbor.Unmarshal(data, new(warnings.List)); err == nil {
+		t.Error("Unmarshal(newer version) = nil; want an error")
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want string // JSON of the decoded Warning, or "" for an error
+	}{
+		// {"message": "m", "line": 2}
+		{"a2676d657373616765616d646c696e6502", `{"message":"m","line":2}`},
+		// Indefinite length map and chunked text string, tagged.
+		{"c0bf676d6573736167657f616d6131ffff", `{"message":"m1"}`},
+		// {"message": "m", "x": [1.5 (half), -3, null, true]}
+		{"a2676d657373616765616d617884f93e0022f6f5", `{"message":"m"}`},
+		{"a1", ""},
+		{"a101617801", ""},
+		{"a0a0", ""},
+		{"fc", ""},
+	}
+	for _, tt := range tests {
+		data, err := hex.DecodeString(tt.hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w warnings.Warning
+		err = warningscbor.Unmarshal(data, &w)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = nil; want an error", tt.hex)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s) = %v", tt.hex, err)
+			continue
+		}
+		if js, _ := json.Marshal(&w); string(js) != tt.want {
+			t.Errorf("Unmarshal(%s) = %s; want %s", tt.hex, js, tt.want)
+		}
+	}
+}
+
+func benchmarkList() warnings.List {
+	var l warnings.List
+	for i := 0; i < 100; i++ {
+		l.Warnings = append(l.Warnings, &warnings.Warning{Code: "C1", Msg: "message",
+			Pos:   warnings.Position{Filename: "file.go", Line: i, Column: 1},
+			Attrs: []warnings.Attr{warnings.Field("n", i)}})
+	}
+	return l
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	l := benchmarkList()
+	for i := 0; i < b.N; i++ {
+		if _, err := warningscbor.Marshal(l); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	l := benchmarkList()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(l); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	data, _ := warningscbor.Marshal(benchmarkList())
+	for i := 0; i < b.N; i++ {
+		var l warnings.List
+		if err := warningscbor.Unmarshal(data, &l); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
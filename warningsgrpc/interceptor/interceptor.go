@@ -0,0 +1,86 @@
+//go:build go1.19
+// +build go1.19
+
+// Package interceptor provides gRPC server interceptors installing a
+// warnings.Collector in the context of each RPC, and passing the warnings
+// collected to the client in trailing metadata (see warningsgrpc.Trailer).
+//
+// Unlike warningsgrpc, it depends on google.golang.org/grpc, and so is only
+// built with Go 1.19 or later:
+//
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(interceptor.Unary(isFatal)),
+//		grpc.StreamInterceptor(interceptor.Stream(isFatal)),
+//	)
+//
+// Handlers collect warnings using the Collector returned by
+// warnings.FromContext.
+package interceptor // import "gopkg.in/warnings.v0/warningsgrpc/interceptor"
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningsgrpc"
+)
+
+// Unary returns a unary server interceptor collecting the warnings of each
+// RPC with a Collector using isFatal. The warnings are set as trailing
+// metadata. The error returned by the handler, however isFatal classifies
+// it, or else the fatal error collected, if any, is returned as the RPC
+// status, with code Internal unless it carries a gRPC status itself.
+func Unary(isFatal func(error) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		c := warnings.NewCollector(isFatal)
+		resp, err := handler(warnings.NewContext(ctx, c), req)
+		fatal := done(c, err, func(md metadata.MD) { grpc.SetTrailer(ctx, md) })
+		if fatal != nil {
+			return nil, fatal
+		}
+		return resp, nil
+	}
+}
+
+// Stream returns a stream server interceptor collecting the warnings of each
+// RPC as Unary does.
+func Stream(isFatal func(error) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		c := warnings.NewCollector(isFatal)
+		err := handler(srv, &stream{ss, warnings.NewContext(ss.Context(), c)})
+		return done(c, err, ss.SetTrailer)
+	}
+}
+
+// stream is a grpc.ServerStream with a context carrying a Collector.
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context { return s.ctx }
+
+// done ends collection by c, sets the warnings as trailing metadata using
+// setTrailer, and returns err, the error returned by the handler, or else the
+// fatal error, if any, as a gRPC status error.
+func done(c *warnings.Collector, err error, setTrailer func(metadata.MD)) error {
+	fatal, warns := warnings.Split(c.Done())
+	if len(warns) > 0 {
+		setTrailer(metadata.MD(warningsgrpc.Trailer(warnings.List{Warnings: warns})))
+	}
+	if err == nil {
+		err = fatal
+	}
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,129 @@
+//go:build go1.19
+// +build go1.19
+
+package interceptor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningsgrpc"
+	"gopkg.in/warnings.v0/warningsgrpc/interceptor"
+)
+
+// fatalErr is the error type classified as fatal by isFatal.
+type fatalErr string
+
+func (e fatalErr) Error() string { return string(e) }
+
+func isFatal(err error) bool {
+	var f fatalErr
+	return errors.As(err, &f) || status.Code(err) != codes.Unknown
+}
+
+// transportStream records the trailer set for a unary RPC.
+type transportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// serverStream records the trailer set for a streaming RPC.
+type serverStream struct {
+	grpc.ServerStream
+	trailer metadata.MD
+}
+
+func (s *serverStream) Context() context.Context { return context.Background() }
+
+func (s *serverStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+// handle collects warn, if set, and returns err.
+func handle(ctx context.Context, warn, err error) error {
+	c, ok := warnings.FromContext(ctx)
+	if !ok {
+		return errors.New("no Collector in context")
+	}
+	if warn != nil {
+		if err := c.Collect(warn); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+var interceptorTests = []struct {
+	warn, err error
+	code      codes.Code
+	warnings  string
+}{
+	{nil, nil, codes.OK, ""},
+	{errors.New("1w"), nil, codes.OK, "warning:\n1w\n"},
+	{errors.New("1w"), errors.New("2e"), codes.Internal, "warning:\n1w\n"},
+	{errors.New("1w"), fatalErr("2f"), codes.Internal, "warning:\n1w\n"},
+	{fatalErr("1f"), nil, codes.Internal, ""},
+	{nil, status.Error(codes.NotFound, "2f"), codes.NotFound, ""},
+}
+
+func TestUnary(t *testing.T) {
+	unary := interceptor.Unary(isFatal)
+	for _, tt := range interceptorTests {
+		ts := &transportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+		resp, err := unary(ctx, "req", &grpc.UnaryServerInfo{},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "resp", handle(ctx, tt.warn, tt.err)
+			})
+		if status.Code(err) != tt.code || (err == nil) != (resp == "resp") {
+			t.Errorf("%v, %v: got %v, %v; want code %v", tt.warn, tt.err,
+				resp, err, tt.code)
+		}
+		if got := warningsgrpc.FromTrailer(ts.trailer); got.Error() != tt.warnings {
+			t.Errorf("%v, %v: trailer warnings %q; want %q", tt.warn, tt.err,
+				got.Error(), tt.warnings)
+		}
+	}
+}
+
+func TestUnaryNilIsFatal(t *testing.T) {
+	unary := interceptor.Unary(nil)
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(),
+		&transportStream{})
+	_, err := unary(ctx, "req", &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("got %v; want code %v", err, codes.Internal)
+	}
+}
+
+func TestStream(t *testing.T) {
+	stream := interceptor.Stream(isFatal)
+	for _, tt := range interceptorTests {
+		ss := &serverStream{}
+		err := stream(nil, ss, &grpc.StreamServerInfo{},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				return handle(ss.Context(), tt.warn, tt.err)
+			})
+		if status.Code(err) != tt.code {
+			t.Errorf("%v, %v: got %v; want code %v", tt.warn, tt.err, err, tt.code)
+		}
+		if got := warningsgrpc.FromTrailer(ss.trailer); got.Error() != tt.warnings {
+			t.Errorf("%v, %v: trailer warnings %q; want %q", tt.warn, tt.err,
+				got.Error(), tt.warnings)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package warningsgrpc provides helpers for passing warnings collected by a
+// gRPC server to its clients in trailing metadata.
+//
+// The package doesn't depend on google.golang.org/grpc; the maps it uses are
+// convertible to and from metadata.MD. Server interceptors installing a
+// Collector in the context of each RPC and setting the trailer are provided
+// by the subpackage interceptor. Clients read the warnings using
+// FromTrailer.
+package warningsgrpc // import "gopkg.in/warnings.v0/warningsgrpc"
+
+import (
+	"encoding/json"
+
+	"gopkg.in/warnings.v0"
+)
+
+// TrailerKey is the metadata key under which warnings are passed, one JSON
+// encoded warning per value (in the format of warnings.Warning.MarshalJSON).
+const TrailerKey = "warning-bin"
+
+// Trailer returns trailing metadata holding the warnings in l. The fatal
+// error is not included, as it is returned as the RPC status. Warnings which
+// can't be encoded (e.g. because of attribute values) are skipped.
+func Trailer(l warnings.List) map[string][]string {
+	md := make(map[string][]string)
+	for _, err := range l.Warnings {
+		w, ok := warnings.AsWarning(err)
+		if !ok {
+			w = &warnings.Warning{Msg: err.Error()}
+		}
+		data, err := json.Marshal(w)
+		if err != nil {
+			continue
+		}
+		md[TrailerKey] = append(md[TrailerKey], string(data))
+	}
+	return md
+}
+
+// FromTrailer returns the warnings held by trailing metadata, as returned by
+// Trailer. Values that can't be decoded are skipped.
+func FromTrailer(md map[string][]string) warnings.List {
+	var l warnings.List
+	for _, v := range md[TrailerKey] {
+		w := new(warnings.Warning)
+		if err := json.Unmarshal([]byte(v), w); err == nil {
+			l.Warnings = append(l.Warnings, w)
+		}
+	}
+	return l
+}
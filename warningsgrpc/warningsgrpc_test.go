@@ -0,0 +1,36 @@
+package warningsgrpc_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningsgrpc"
+)
+
+func TestTrailer(t *testing.T) {
+	l := warnings.List{
+		Warnings: []error{
+			errors.New("1w"),
+			&warnings.Warning{Code: "C2", Severity: warnings.Error, Msg: "2w"},
+		},
+		Fatal: errors.New("3f"),
+	}
+	md := warningsgrpc.Trailer(l)
+	want := []string{`{"message":"1w"}`,
+		`{"code":"C2","severity":"error","message":"2w"}`}
+	if got := md[warningsgrpc.TrailerKey]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Trailer() = %q; want %q", got, want)
+	}
+	l.Warnings[1] = &warnings.CausedError{Err: l.Warnings[1], Cause: errors.New("cause")}
+	md = warningsgrpc.Trailer(l)
+	if got := md[warningsgrpc.TrailerKey]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Trailer() = %q; want %q", got, want)
+	}
+	md[warningsgrpc.TrailerKey] = append(md[warningsgrpc.TrailerKey], "bad")
+	got := warningsgrpc.FromTrailer(md)
+	if want := "warnings:\n1w\n[C2] 2w\n"; got.Error() != want {
+		t.Errorf("FromTrailer() = %q; want %q", got.Error(), want)
+	}
+}
@@ -0,0 +1,99 @@
+//go:build go1.18
+// +build go1.18
+
+package warningshttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/warnings.v0"
+)
+
+// A Result is the outcome of a single item of a bulk request: its value, and
+// the warnings collected for it. The item failed if List has a fatal error.
+type Result[T any] struct {
+	Value T
+	List  warnings.List
+}
+
+// multiStatus is the body written by WriteMultiStatus.
+type multiStatus[T any] struct {
+	Items    []multiStatusItem[T] `json:"items"`
+	Warnings []*warnings.Warning  `json:"warnings,omitempty"`
+	Fatal    *warnings.Warning    `json:"fatal,omitempty"`
+}
+
+// multiStatusItem is the entry of a Result in a multiStatus.
+type multiStatusItem[T any] struct {
+	Status   int                 `json:"status"`
+	Value    *T                  `json:"value,omitempty"`
+	Warnings []*warnings.Warning `json:"warnings,omitempty"`
+	Error    *warnings.Warning   `json:"error,omitempty"`
+}
+
+// WriteMultiStatus writes a JSON response reporting the partial success of
+// a bulk request: for each of results, in order, its status (200 if it
+// succeeded, 422 if it failed), its value if it succeeded, its warnings and
+// its fatal error, followed by the warnings and fatal error of l, which
+// apply to the request as a whole. For example:
+//
+//	{"items":[{"status":200,"value":{...},"warnings":[{"message":"..."}]},
+//		{"status":422,"error":{"code":"CFG012","message":"..."}}],
+//	 "warnings":[...]}
+//
+// Warnings and errors are encoded as by warnings.Warning.MarshalJSON. The
+// response status is 500 if l has a fatal error, 207 (Multi-Status) if any
+// of results failed, and 200 otherwise.
+func WriteMultiStatus[T any](w http.ResponseWriter, results []Result[T], l warnings.List) error {
+	body := multiStatus[T]{
+		Items:    make([]multiStatusItem[T], len(results)),
+		Warnings: toWarnings(l.Warnings),
+		Fatal:    toWarning(l.Fatal),
+	}
+	status := http.StatusOK
+	for i := range results {
+		r := &results[i]
+		item := &body.Items[i]
+		item.Warnings = toWarnings(r.List.Warnings)
+		if r.List.Fatal != nil {
+			item.Status = http.StatusUnprocessableEntity
+			item.Error = toWarning(r.List.Fatal)
+			status = http.StatusMultiStatus
+		} else {
+			item.Status = http.StatusOK
+			item.Value = &r.Value
+		}
+	}
+	if l.Fatal != nil {
+		status = http.StatusInternalServerError
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// toWarning returns err as a *warnings.Warning, for encoding; other errors
+// are represented by their message.
+func toWarning(err error) *warnings.Warning {
+	if err == nil {
+		return nil
+	}
+	if w, ok := warnings.AsWarning(err); ok {
+		return w
+	}
+	return &warnings.Warning{Msg: err.Error()}
+}
+
+func toWarnings(errs []error) []*warnings.Warning {
+	var ws []*warnings.Warning
+	for _, err := range errs {
+		ws = append(ws, toWarning(err))
+	}
+	return ws
+}
@@ -0,0 +1,60 @@
+//go:build go1.18
+// +build go1.18
+
+package warningshttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningshttp"
+)
+
+func TestWriteMultiStatus(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	ok := warningshttp.Result[item]{Value: item{1},
+		List: warnings.List{Warnings: []error{errors.New("1w")}}}
+	failed := warningshttp.Result[item]{Value: item{2},
+		List: warnings.List{Fatal: &warnings.CausedError{
+			Err: &warnings.Warning{Code: "C2", Msg: "2f"}, Cause: errors.New("cause")}}}
+	tests := []struct {
+		results []warningshttp.Result[item]
+		l       warnings.List
+		status  int
+		body    string
+	}{
+		{nil, warnings.List{}, http.StatusOK, `{"items":[]}`},
+		{[]warningshttp.Result[item]{ok}, warnings.List{}, http.StatusOK,
+			`{"items":[{"status":200,"value":{"id":1},"warnings":[{"message":"1w"}]}]}`},
+		{[]warningshttp.Result[item]{ok, failed},
+			warnings.List{Warnings: []error{errors.New("3w")}},
+			http.StatusMultiStatus,
+			`{"items":[{"status":200,"value":{"id":1},"warnings":[{"message":"1w"}]},` +
+				`{"status":422,"error":{"code":"C2","message":"2f"}}],` +
+				`"warnings":[{"message":"3w"}]}`},
+		{[]warningshttp.Result[item]{ok}, warnings.List{Fatal: errors.New("4f")},
+			http.StatusInternalServerError,
+			`{"items":[{"status":200,"value":{"id":1},"warnings":[{"message":"1w"}]}],` +
+				`"fatal":{"message":"4f"}}`},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		if err := warningshttp.WriteMultiStatus(rec, tt.results, tt.l); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Code != tt.status {
+			t.Errorf("status = %d; want %d", rec.Code, tt.status)
+		}
+		if got := rec.Body.String(); got != tt.body+"\n" {
+			t.Errorf("body = %s; want %s", got, tt.body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q; want application/json", ct)
+		}
+	}
+}
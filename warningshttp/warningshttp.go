@@ -0,0 +1,109 @@
+// Package warningshttp provides net/http middleware collecting warnings per
+// request, and WriteMultiStatus for reporting the partial success of bulk
+// requests.
+//
+// The middleware installs a Collector in the request context, where handlers
+// retrieve it using warnings.FromContext:
+//
+//	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//		c, _ := warnings.FromContext(r.Context())
+//		c.Collect(...)
+//	})
+//	http.ListenAndServe(addr, warningshttp.Options{Log: logWarnings}.Handler(mux))
+package warningshttp // import "gopkg.in/warnings.v0/warningshttp"
+
+import (
+	"net/http"
+	"strconv"
+
+	"gopkg.in/warnings.v0"
+)
+
+// Options controls the middleware returned by Handler.
+type Options struct {
+	// IsFatal distinguishes between warnings and fatal errors; if nil,
+	// errors with severity Error or higher are fatal, as for
+	// warnings.Collector.IsFatal.
+	IsFatal func(error) bool
+	// CountHeader, if set, is the name of a response header set to the number
+	// of warnings collected before the response header was written (or by
+	// the time the handler returned, if it didn't write anything).
+	CountHeader string
+	// SeverityHeader, if set, is the name of a response header set to the
+	// highest severity of those warnings (see warnings.SeverityOf).
+	SeverityHeader string
+	// Log, if set, is called with the error returned by Collector.Done once
+	// the request has been handled, unless it is nil.
+	Log func(r *http.Request, err error)
+}
+
+// Handler returns a handler calling next with a Collector installed in the
+// request context.
+func (o Options) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := warnings.NewCollector(o.IsFatal)
+		rw := &responseWriter{ResponseWriter: w, o: &o, c: c}
+		next.ServeHTTP(rw, r.WithContext(warnings.NewContext(r.Context(), c)))
+		rw.setHeaders() // for an implicit response
+		if err := c.Done(); err != nil && o.Log != nil {
+			o.Log(r, err)
+		}
+	})
+}
+
+// responseWriter sets the headers of the Options when the response header is
+// written.
+type responseWriter struct {
+	http.ResponseWriter
+	o           *Options
+	c           *warnings.Collector
+	wroteHeader bool
+}
+
+func (w *responseWriter) setHeaders() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.o.CountHeader == "" && w.o.SeverityHeader == "" {
+		return
+	}
+	l := w.c.List()
+	if len(l.Warnings) == 0 {
+		return
+	}
+	max := warnings.Info
+	for _, err := range l.Warnings {
+		if s := warnings.SeverityOf(err); s > max {
+			max = s
+		}
+	}
+	h := w.Header()
+	if w.o.CountHeader != "" {
+		h.Set(w.o.CountHeader, strconv.Itoa(len(l.Warnings)))
+	}
+	if w.o.SeverityHeader != "" {
+		h.Set(w.o.SeverityHeader, max.String())
+	}
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.setHeaders()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher, if the underlying ResponseWriter does.
+func (w *responseWriter) Flush() {
+	w.setHeaders()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
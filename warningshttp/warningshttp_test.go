@@ -0,0 +1,85 @@
+package warningshttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningshttp"
+)
+
+func TestHandler(t *testing.T) {
+	var logged error
+	o := warningshttp.Options{
+		IsFatal:        func(error) bool { return false },
+		CountHeader:    "Warning-Count",
+		SeverityHeader: "Warning-Severity",
+		Log:            func(r *http.Request, err error) { logged = err },
+	}
+	h := o.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := warnings.FromContext(r.Context())
+		if !ok {
+			t.Fatal("no Collector in request context")
+		}
+		c.Collect(errors.New("1w"))
+		c.Collect(&warnings.Warning{Severity: warnings.Error, Msg: "2w"})
+		w.Write([]byte("ok"))
+		c.Collect(errors.New("3w"))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if got := rec.Header().Get("Warning-Count"); got != "2" {
+		t.Errorf("Warning-Count = %q; want 2", got)
+	}
+	if got := rec.Header().Get("Warning-Severity"); got != "error" {
+		t.Errorf("Warning-Severity = %q; want error", got)
+	}
+	want := "warnings:\n1w\n2w\n3w\n"
+	if logged == nil || logged.Error() != want {
+		t.Errorf("logged %v; want %q", logged, want)
+	}
+}
+
+func TestHandlerSeverityDefault(t *testing.T) {
+	var logged error
+	o := warningshttp.Options{Log: func(r *http.Request, err error) { logged = err }}
+	e := &warnings.Warning{Severity: warnings.Error, Msg: "1e"}
+	h := o.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, _ := warnings.FromContext(r.Context())
+		c.Collect(errors.New("1w"))
+		c.Collect(e)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if fatal, _ := warnings.Split(logged); fatal != e {
+		t.Errorf("logged fatal %v; want %v by severity", fatal, e)
+	}
+}
+
+func TestHandlerImplicitResponse(t *testing.T) {
+	o := warningshttp.Options{CountHeader: "Warning-Count"}
+	h := o.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, _ := warnings.FromContext(r.Context())
+		c.Collect(errors.New("1w"))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if got := rec.Header().Get("Warning-Count"); got != "1" {
+		t.Errorf("Warning-Count = %q; want 1 without a write", got)
+	}
+}
+
+func TestHandlerNoWarnings(t *testing.T) {
+	o := warningshttp.Options{CountHeader: "Warning-Count",
+		Log: func(r *http.Request, err error) { t.Errorf("logged %v", err) }}
+	h := o.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if _, ok := rec.Header()["Warning-Count"]; ok || rec.Code != http.StatusNoContent {
+		t.Errorf("response = %d %v; want 204 without Warning-Count", rec.Code,
+			rec.Header())
+	}
+}
@@ -0,0 +1,32 @@
+//go:build go1.19
+// +build go1.19
+
+// Package warningsmultierr converts between warnings and go.uber.org/multierr,
+// for code bases standardized on multierr adopting warnings incrementally:
+// errors combined using multierr.Append are collected one by one, so that
+// each is classified by the Collector. As multierr, it requires Go 1.19 or
+// later.
+package warningsmultierr // import "gopkg.in/warnings.v0/warningsmultierr"
+
+import (
+	"go.uber.org/multierr"
+	"gopkg.in/warnings.v0"
+)
+
+// CollectEach collects the errors combined in err (see multierr.Errors) one
+// by one using c.Collect. Errors following the first fatal error are dropped.
+// The result is that of the last call to Collect.
+func CollectEach(c *warnings.Collector, err error) error {
+	for _, err := range multierr.Errors(err) {
+		if cerr := c.Collect(err); cerr != nil {
+			return cerr
+		}
+	}
+	return nil
+}
+
+// Combine returns the warnings in l, followed by its fatal errors, combined
+// into a single error using multierr.Combine, or nil if l holds none.
+func Combine(l warnings.List) error {
+	return multierr.Combine(l.ToErrors(true)...)
+}
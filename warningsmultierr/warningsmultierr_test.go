@@ -0,0 +1,53 @@
+//go:build go1.19
+// +build go1.19
+
+package warningsmultierr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/multierr"
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningsmultierr"
+)
+
+// fatalErr is the error type classified as fatal by isFatal.
+type fatalErr string
+
+func (e fatalErr) Error() string { return string(e) }
+
+func isFatal(err error) bool {
+	_, ok := err.(fatalErr)
+	return ok
+}
+
+func TestCollectEach(t *testing.T) {
+	w1, w2, w4 := errors.New("1w"), errors.New("2w"), errors.New("4w")
+	f3 := fatalErr("3f")
+	c := warnings.NewCollector(isFatal)
+	c.FatalWithWarnings = true
+	if err := warningsmultierr.CollectEach(c, multierr.Append(w1, nil)); err != nil {
+		t.Errorf("CollectEach(warnings) = %v; want nil", err)
+	}
+	err := warningsmultierr.CollectEach(c, multierr.Combine(w2, f3, w4))
+	if err == nil {
+		t.Errorf("CollectEach(fatal) = nil; want an error")
+	}
+	want := warnings.List{Warnings: []error{w1, w2}, Fatal: f3}
+	if got := c.Done(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Done() = %#v; want %#v", got, want)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	if err := warningsmultierr.Combine(warnings.List{}); err != nil {
+		t.Errorf("Combine(empty) = %v; want nil", err)
+	}
+	w1, f2 := errors.New("1w"), fatalErr("2f")
+	err := warningsmultierr.Combine(warnings.List{Warnings: []error{w1}, Fatal: f2})
+	if got := multierr.Errors(err); !reflect.DeepEqual(got, []error{w1, f2}) {
+		t.Errorf("Errors(Combine()) = %v; want [1w 2f]", got)
+	}
+}
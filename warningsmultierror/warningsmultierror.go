@@ -0,0 +1,44 @@
+// Package warningsmultierror converts between warnings.List and
+// github.com/hashicorp/go-multierror, for code bases using both libraries
+// (e.g. while migrating from one to the other), without converting errors to
+// strings.
+package warningsmultierror // import "gopkg.in/warnings.v0/warningsmultierror"
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/warnings.v0"
+)
+
+// FromMultierror returns a List holding the errors in err, using isFatal to
+// distinguish between warnings and fatal errors (see warnings.FromErrors).
+// Nested *multierror.Error values are flattened; any other error is kept as
+// a single error.
+func FromMultierror(err error, isFatal func(error) bool) warnings.List {
+	return warnings.FromErrors(flatten(err, nil), isFatal)
+}
+
+// flatten appends the errors in err to errs, recursively if err is a
+// *multierror.Error.
+func flatten(err error, errs []error) []error {
+	merr, ok := err.(*multierror.Error)
+	switch {
+	case err == nil:
+		return errs
+	case !ok:
+		return append(errs, err)
+	}
+	for _, err := range merr.Errors {
+		errs = flatten(err, errs)
+	}
+	return errs
+}
+
+// AsMultierror returns a *multierror.Error holding the warnings in l,
+// followed by its fatal errors, or nil if l holds none.
+func AsMultierror(l warnings.List) *multierror.Error {
+	errs := l.ToErrors(true)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &multierror.Error{Errors: errs}
+}
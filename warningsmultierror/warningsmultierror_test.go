@@ -0,0 +1,57 @@
+package warningsmultierror_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningsmultierror"
+)
+
+// fatalErr is the error type classified as fatal by isFatal.
+type fatalErr string
+
+func (e fatalErr) Error() string { return string(e) }
+
+func isFatal(err error) bool {
+	_, ok := err.(fatalErr)
+	return ok
+}
+
+func TestFromMultierror(t *testing.T) {
+	w1, w2, w4 := errors.New("1w"), errors.New("2w"), errors.New("4w")
+	f3, f5 := fatalErr("3f"), fatalErr("5f")
+	tests := []struct {
+		err  error
+		want warnings.List
+	}{
+		{nil, warnings.List{}},
+		{w1, warnings.List{Warnings: []error{w1}}},
+		{multierror.Append(w1, multierror.Append(w2, f3), nil, w4, f5),
+			warnings.List{Warnings: []error{w1, w2, w4}, Fatal: f3,
+				Fatals: []error{f3, f5}}},
+	}
+	for _, tt := range tests {
+		got := warningsmultierror.FromMultierror(tt.err, isFatal)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FromMultierror(%v) = %#v; want %#v", tt.err, got, tt.want)
+		}
+		merr := warningsmultierror.AsMultierror(got)
+		if rt := warningsmultierror.FromMultierror(merr.ErrorOrNil(), isFatal); !reflect.DeepEqual(rt, got) {
+			t.Errorf("round trip of %v = %#v; want %#v", tt.err, rt, got)
+		}
+	}
+}
+
+func TestAsMultierror(t *testing.T) {
+	if got := warningsmultierror.AsMultierror(warnings.List{}); got != nil {
+		t.Errorf("AsMultierror(empty) = %v; want nil", got)
+	}
+	w1, f2 := errors.New("1w"), fatalErr("2f")
+	merr := warningsmultierror.AsMultierror(warnings.List{Warnings: []error{w1}, Fatal: f2})
+	if !reflect.DeepEqual(merr.Errors, []error{w1, f2}) {
+		t.Errorf("AsMultierror().Errors = %v; want [1w 2f]", merr.Errors)
+	}
+}
@@ -0,0 +1,95 @@
+package warningstest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+)
+
+// update is namespaced so as not to clash with an -update flag of the test
+// binary importing the package.
+var update = flag.Bool("warningstest.update", false, "update golden files of warningstest.AssertGolden")
+
+// updating reports whether golden files are to be updated, according to the
+// -warningstest.update flag or the WARNINGSTEST_UPDATE environment variable.
+func updating() bool {
+	return *update || os.Getenv("WARNINGSTEST_UPDATE") != ""
+}
+
+// Render renders the errors in err deterministically: the warnings are sorted
+// by position and message, and file names in the positions of *Warning values
+// are made relative to the current directory (if below it) and use forward
+// slashes.
+func Render(err error) string {
+	fatal, warns := warnings.Split(err)
+	l := warnings.List{Fatal: normalize(fatal)}
+	for _, w := range warns {
+		l.Warnings = append(l.Warnings, normalize(w))
+	}
+	sort.SliceStable(l.Warnings, func(i, j int) bool {
+		pi, pj := position(l.Warnings[i]), position(l.Warnings[j])
+		switch {
+		case pi.Filename != pj.Filename:
+			return pi.Filename < pj.Filename
+		case pi.Line != pj.Line:
+			return pi.Line < pj.Line
+		case pi.Column != pj.Column:
+			return pi.Column < pj.Column
+		}
+		return l.Warnings[i].Error() < l.Warnings[j].Error()
+	})
+	return l.Error()
+}
+
+func position(err error) warnings.Position {
+	if w, ok := warnings.AsWarning(err); ok {
+		return w.Pos
+	}
+	return warnings.Position{}
+}
+
+func normalize(err error) error {
+	w, ok := warnings.AsWarning(err)
+	if !ok || w.Pos.Filename == "" {
+		return err
+	}
+	nw := *w
+	name := nw.Pos.Filename
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(name) {
+		if rel, err := filepath.Rel(wd, name); err == nil &&
+			!strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+	}
+	nw.Pos.Filename = filepath.ToSlash(name)
+	return &nw
+}
+
+// AssertGolden reports an error if Render(err) differs from the contents of
+// the golden file. If the test binary is run with the -warningstest.update
+// flag, or with the environment variable WARNINGSTEST_UPDATE set (e.g. for
+// "go test ./..." across packages), the golden file is written instead.
+func AssertGolden(t testing.TB, err error, golden string) {
+	t.Helper()
+	got := Render(err)
+	if updating() {
+		if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+	want, rerr := ioutil.ReadFile(golden)
+	if rerr != nil {
+		t.Fatalf("reading golden file: %v", rerr)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch (run with -warningstest.update to update):\ngot:\n%s\nwant:\n%s",
+			golden, got, want)
+	}
+}
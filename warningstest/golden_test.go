@@ -0,0 +1,67 @@
+package warningstest_test
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningstest"
+)
+
+func TestRender(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := warnings.List{
+		Warnings: []error{
+			&warnings.Warning{Msg: "b2", Pos: warnings.Position{Filename: "b", Line: 2}},
+			&warnings.Warning{Msg: "a", Pos: warnings.Position{
+				Filename: filepath.Join(wd, "testdata", "a.cfg"), Line: 1}},
+			&warnings.CausedError{
+				Err:   &warnings.Warning{Msg: "b1", Pos: warnings.Position{Filename: "b", Line: 1}},
+				Cause: errors.New("cause"),
+			},
+			errors.New("plain"),
+		},
+		Fatal: errors.New("fatal"),
+	}
+	want := "fatal:\nfatal\nwarnings:\nplain\nb:1: b1\nb:2: b2\ntestdata/a.cfg:1: a\n"
+	if got := warningstest.Render(l); got != want {
+		t.Errorf("Render() = %q; want %q", got, want)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "warningstest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "golden.txt")
+	l := warnings.List{Warnings: []error{w2, w1}}
+	if err := flag.Set("warningstest.update", "true"); err != nil {
+		t.Fatal(err)
+	}
+	warningstest.AssertGolden(t, l, golden)
+	flag.Set("warningstest.update", "false")
+	if data, _ := ioutil.ReadFile(golden); string(data) != "warnings:\n1w\n2w\n" {
+		t.Errorf("golden file = %q after update", data)
+	}
+	warningstest.AssertGolden(t, warnings.List{Warnings: []error{w1, w2}}, golden)
+	r := &recorder{TB: t}
+	warningstest.AssertGolden(r, warnings.List{Warnings: []error{w1}}, golden)
+	if !r.failed {
+		t.Errorf("AssertGolden() didn't fail for mismatching golden file")
+	}
+	os.Setenv("WARNINGSTEST_UPDATE", "1")
+	defer os.Unsetenv("WARNINGSTEST_UPDATE")
+	warningstest.AssertGolden(t, warnings.List{Warnings: []error{w1}}, golden)
+	if data, _ := ioutil.ReadFile(golden); string(data) != "warning:\n1w\n" {
+		t.Errorf("golden file = %q after update by environment", data)
+	}
+}
@@ -0,0 +1,112 @@
+package warningstest
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/warnings.v0"
+)
+
+// A Call records a single call to RecordingCollector.Collect.
+type Call struct {
+	Err    error           // the error passed to Collect
+	Attrs  []warnings.Attr // the attributes passed to Collect
+	Result error           // the error returned by Collect
+	Time   time.Time       // time of the call
+	File   string          // file name of the caller
+	Line   int             // line number of the caller
+}
+
+// A RecordingCollector is a warnings.Interface that records every call to
+// Collect, allowing tests to check exactly what a function collected without
+// going through Done. Otherwise it behaves like a warnings.Collector.
+type RecordingCollector struct {
+	// CallerSkip is the number of additional stack frames to skip when
+	// recording the caller, e.g. for a helper calling Collect.
+	CallerSkip int
+	// SkipPrefixes lists prefixes of function names (such as
+	// "example.com/mylib.") whose frames are skipped when recording the
+	// caller, so that it points at the code under test rather than at
+	// wrappers in those packages.
+	SkipPrefixes []string
+
+	c     *warnings.Collector
+	calls []Call
+}
+
+var _ warnings.Interface = &RecordingCollector{}
+
+// NewRecordingCollector returns a new RecordingCollector; it uses isFatal to
+// distinguish between warnings and fatal errors.
+func NewRecordingCollector(isFatal func(error) bool) *RecordingCollector {
+	return &RecordingCollector{c: warnings.NewCollector(isFatal)}
+}
+
+// Collect records the call and collects err as warnings.Collector.Collect.
+func (r *RecordingCollector) Collect(err error, attrs ...warnings.Attr) error {
+	call := Call{Err: err, Attrs: attrs, Time: time.Now()}
+	call.File, call.Line = r.caller()
+	call.Result = r.c.Collect(err, attrs...)
+	r.calls = append(r.calls, call)
+	return call.Result
+}
+
+// caller returns the location of the caller of Collect, as configured by
+// CallerSkip and SkipPrefixes.
+func (r *RecordingCollector) caller() (file string, line int) {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(3+r.CallerSkip, pcs)]
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if !r.skip(f.Function) || !more {
+			return f.File, f.Line
+		}
+	}
+}
+
+// skip reports whether fn has any of the prefixes in SkipPrefixes.
+func (r *RecordingCollector) skip(fn string) bool {
+	for _, p := range r.SkipPrefixes {
+		if strings.HasPrefix(fn, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Done ends collection as warnings.Collector.Done.
+func (r *RecordingCollector) Done() error {
+	return r.c.Done()
+}
+
+// Calls returns all calls to Collect so far, including those with a nil
+// error.
+func (r *RecordingCollector) Calls() []Call {
+	return append([]Call(nil), r.calls...)
+}
+
+// Errors returns the non-nil errors collected so far, in order.
+func (r *RecordingCollector) Errors() []error {
+	var errs []error
+	for _, c := range r.calls {
+		if c.Err != nil {
+			errs = append(errs, c.Err)
+		}
+	}
+	return errs
+}
+
+// Find returns the calls to Collect with an error matching target (according
+// to errors.Is).
+func (r *RecordingCollector) Find(target error) []Call {
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Err != nil && errors.Is(c.Err, target) {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
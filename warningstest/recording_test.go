@@ -0,0 +1,69 @@
+package warningstest_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningstest"
+)
+
+func isFatal(err error) bool { return err == f3 }
+
+func collectAll(c warnings.Interface) error {
+	c.Collect(w1)
+	c.Collect(nil)
+	c.Collect(w2)
+	c.Collect(w1)
+	return c.Done()
+}
+
+func TestRecordingCollector(t *testing.T) {
+	r := warningstest.NewRecordingCollector(isFatal)
+	err := collectAll(r)
+	warningstest.AssertWarningCount(t, err, 3)
+	calls := r.Calls()
+	if len(calls) != 4 {
+		t.Fatalf("Calls() = %v; want 4 calls", calls)
+	}
+	if filepath.Base(calls[0].File) != "recording_test.go" || calls[0].Line == 0 {
+		t.Errorf("Calls()[0] at %s:%d; want caller", calls[0].File, calls[0].Line)
+	}
+	if want := []error{w1, w2, w1}; !reflect.DeepEqual(r.Errors(), want) {
+		t.Errorf("Errors() = %v; want %v", r.Errors(), want)
+	}
+	if got := r.Find(w1); len(got) != 2 {
+		t.Errorf("Find(%v) = %v; want 2 calls", w1, got)
+	}
+	if got := r.Find(errors.New("other")); len(got) != 0 {
+		t.Errorf("Find(other) = %v; want none", got)
+	}
+}
+
+// collectVia is a helper wrapping Collect, as found in libraries.
+func collectVia(c warnings.Interface, err error) {
+	c.Collect(err)
+}
+
+func TestRecordingCollectorCaller(t *testing.T) {
+	tests := []struct {
+		skip     int
+		prefixes []string
+	}{
+		{1, nil},
+		{0, []string{"gopkg.in/warnings.v0/warningstest_test.collectVia"}},
+	}
+	for _, tt := range tests {
+		r := warningstest.NewRecordingCollector(isFatal)
+		r.CallerSkip, r.SkipPrefixes = tt.skip, tt.prefixes
+		_, _, line, _ := runtime.Caller(0)
+		collectVia(r, w1)
+		if got := r.Calls()[0].Line; got != line+1 {
+			t.Errorf("skip %d, prefixes %q: Line = %d; want %d",
+				tt.skip, tt.prefixes, got, line+1)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package warningstest provides test assertions for errors returned by a
+// warnings.Collector.
+//
+// All assertions accept any error returned by a Collector (a List, a
+// *FatalError or a plain fatal error), as well as errors wrapping a List.
+package warningstest // import "gopkg.in/warnings.v0/warningstest"
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+)
+
+// AssertNoWarnings reports an error if err holds any warnings.
+func AssertNoWarnings(t testing.TB, err error) {
+	t.Helper()
+	if _, warns := warnings.Split(err); len(warns) > 0 {
+		t.Errorf("got %d warning(s), want none:\n%v", len(warns), err)
+	}
+}
+
+// AssertWarning reports an error if err holds no warning matching target
+// (according to errors.Is).
+func AssertWarning(t testing.TB, err error, target error) {
+	t.Helper()
+	_, warns := warnings.Split(err)
+	for _, w := range warns {
+		if errors.Is(w, target) {
+			return
+		}
+	}
+	t.Errorf("got no warning matching %v in:\n%v", target, err)
+}
+
+// AssertMatch reports an error if err holds no warning matched by m, e.g.
+// warnings.MatchCode("CFG012").
+func AssertMatch(t testing.TB, err error, m warnings.Matcher) {
+	t.Helper()
+	_, warns := warnings.Split(err)
+	for _, w := range warns {
+		if m(w) {
+			return
+		}
+	}
+	t.Errorf("got no matching warning in:\n%v", err)
+}
+
+// AssertFatal reports an error if err holds no fatal error, or one not
+// matching target (according to errors.Is). A nil target matches any fatal
+// error.
+func AssertFatal(t testing.TB, err error, target error) {
+	t.Helper()
+	fatal, _ := warnings.Split(err)
+	switch {
+	case fatal == nil:
+		t.Errorf("got no fatal error, want %v", target)
+	case target != nil && !errors.Is(fatal, target):
+		t.Errorf("got fatal error %v, want %v", fatal, target)
+	}
+}
+
+// AssertWarningCount reports an error if err doesn't hold exactly n warnings.
+func AssertWarningCount(t testing.TB, err error, n int) {
+	t.Helper()
+	if _, warns := warnings.Split(err); len(warns) != n {
+		t.Errorf("got %d warning(s), want %d:\n%v", len(warns), n, err)
+	}
+}
@@ -0,0 +1,69 @@
+package warningstest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gopkg.in/warnings.v0"
+	"gopkg.in/warnings.v0/warningstest"
+)
+
+// recorder records whether an assertion reported an error.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper()                                   {}
+func (r *recorder) Errorf(format string, args ...interface{}) { r.failed = true }
+
+var (
+	w1 = errors.New("1w")
+	w2 = errors.New("2w")
+	f3 = errors.New("3f")
+	l  = warnings.List{Warnings: []error{w1, w2}, Fatal: f3}
+)
+
+var assertTests = []struct {
+	name   string
+	assert func(t testing.TB)
+	failed bool
+}{
+	{"no warnings nil", func(t testing.TB) { warningstest.AssertNoWarnings(t, nil) }, false},
+	{"no warnings fatal", func(t testing.TB) { warningstest.AssertNoWarnings(t, f3) }, false},
+	{"no warnings list", func(t testing.TB) { warningstest.AssertNoWarnings(t, l) }, true},
+	{"warning", func(t testing.TB) { warningstest.AssertWarning(t, l, w2) }, false},
+	{"warning wrapped", func(t testing.TB) {
+		warningstest.AssertWarning(t, fmt.Errorf("ctx: %w", l), w1)
+	}, false},
+	{"warning missing", func(t testing.TB) { warningstest.AssertWarning(t, l, f3) }, true},
+	{"match", func(t testing.TB) {
+		warningstest.AssertMatch(t, l, func(err error) bool { return err == w2 })
+	}, false},
+	{"match fatal", func(t testing.TB) {
+		warningstest.AssertMatch(t, l, func(err error) bool { return err == f3 })
+	}, true},
+	{"fatal", func(t testing.TB) { warningstest.AssertFatal(t, l, f3) }, false},
+	{"fatal any", func(t testing.TB) { warningstest.AssertFatal(t, f3, nil) }, false},
+	{"fatal error", func(t testing.TB) {
+		warningstest.AssertFatal(t, &warnings.FatalError{List: l}, f3)
+	}, false},
+	{"fatal other", func(t testing.TB) { warningstest.AssertFatal(t, l, w1) }, true},
+	{"fatal missing", func(t testing.TB) {
+		warningstest.AssertFatal(t, warnings.List{Warnings: l.Warnings}, nil)
+	}, true},
+	{"count", func(t testing.TB) { warningstest.AssertWarningCount(t, l, 2) }, false},
+	{"count nil", func(t testing.TB) { warningstest.AssertWarningCount(t, nil, 0) }, false},
+	{"count wrong", func(t testing.TB) { warningstest.AssertWarningCount(t, l, 1) }, true},
+}
+
+func TestAssertions(t *testing.T) {
+	for _, tt := range assertTests {
+		r := &recorder{TB: t}
+		tt.assert(r)
+		if r.failed != tt.failed {
+			t.Errorf("%s: failed = %v; want %v", tt.name, r.failed, tt.failed)
+		}
+	}
+}
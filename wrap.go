@@ -0,0 +1,47 @@
+package warnings
+
+// A WrappedError is an error together with a List of warnings, as returned by
+// Wrap.
+type WrappedError struct {
+	Err  error
+	List List
+}
+
+// Wrap returns an error whose message is that of err followed by a summary of
+// l (see List.Compact), and which unwraps to err, l and the errors in l. It
+// allows intermediate layers to add context to an error without discarding
+// the warnings. If l is empty, err is returned; if err is nil, l is returned
+// (or nil if l is empty).
+func Wrap(err error, l List) error {
+	empty := l.Fatal == nil && l.count() == 0
+	switch {
+	case empty:
+		return err
+	case err == nil:
+		return l
+	}
+	return &WrappedError{err, l}
+}
+
+// Error implements the error interface.
+func (e *WrappedError) Error() string {
+	return e.Err.Error() + " (" + e.List.Compact() + ")"
+}
+
+// Unwrap returns the wrapped error and List, followed by the errors in the
+// List (see List.ToErrors).
+func (e *WrappedError) Unwrap() []error {
+	return append([]error{e.Err, e.List}, e.List.ToErrors(true)...)
+}
+
+// Is reports whether the wrapped error, the List or any error in it matches
+// target.
+func (e *WrappedError) Is(target error) bool {
+	return (&joinedError{errs: e.Unwrap()}).Is(target)
+}
+
+// As finds the first of the wrapped error, the List and the errors in it that
+// matches target.
+func (e *WrappedError) As(target interface{}) bool {
+	return (&joinedError{errs: e.Unwrap()}).As(target)
+}
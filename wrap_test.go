@@ -0,0 +1,52 @@
+package warnings_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	w "gopkg.in/warnings.v0"
+)
+
+func TestWrap(t *testing.T) {
+	base := errors.New("open failed")
+	cause := fmt.Errorf("loading config: %w", base)
+	l := w.List{Warnings: []error{warning("1w"), warning("2w")}}
+	err := w.Wrap(cause, l)
+	if want := "loading config: open failed (warnings: 1w; 2w)"; err.Error() != want {
+		t.Errorf("Error() = %q; want %q", err.Error(), want)
+	}
+	if !errors.Is(err, base) {
+		t.Errorf("errors.Is(err, base) = false; want true")
+	}
+	var got w.List
+	if !errors.As(err, &got) || len(got.Warnings) != 2 {
+		t.Errorf("errors.As(err, &List) = %v; want %v", got, l)
+	}
+	var ww warn
+	if !errors.As(err, &ww) || ww != "1w" {
+		t.Errorf("errors.As(err, &warn) = %q; want 1w", ww)
+	}
+	// Is is called directly, as errors.Is uses Unwrap instead as of Go 1.20.
+	if is, ok := err.(interface{ Is(error) bool }); !ok || !is.Is(warning("2w")) {
+		t.Errorf("%v.Is(2w) = false; want true", err)
+	}
+	if fatal, warns := w.Split(fmt.Errorf("main: %w", err)); fatal != cause ||
+		len(warns) != 2 {
+		t.Errorf("Split() = %v, %v; want %v and 2 warnings", fatal, warns, cause)
+	}
+}
+
+func TestWrapEmpty(t *testing.T) {
+	cause := errors.New("cause")
+	if err := w.Wrap(cause, w.List{}); err != cause {
+		t.Errorf("Wrap(err, empty) = %v; want %v", err, cause)
+	}
+	if err := w.Wrap(nil, w.List{}); err != nil {
+		t.Errorf("Wrap(nil, empty) = %v; want nil", err)
+	}
+	l := w.List{Warnings: []error{warning("1w")}}
+	if err, ok := w.Wrap(nil, l).(w.List); !ok || len(err.Warnings) != 1 {
+		t.Errorf("Wrap(nil, l) = %v; want %v", err, l)
+	}
+}